@@ -21,13 +21,20 @@ type Base struct {
 // MakeBase used only for internal purposes
 func MakeBase() Base {
 	b := Base{
-		id:   baseNextID,
+		id:   NextID(),
 		name: "",
 	}
-	baseNextID++
 	return b
 }
 
+// NextID returns new unique id, ids are shared by everything that embeds Base and other objects,
+// e.g. ecs entities, so they never collide.
+func NextID() IDType {
+	id := baseNextID
+	baseNextID++
+	return id
+}
+
 func (b *Base) GetID() IDType    { return b.id }
 func (b *Base) GetName() string  { return b.name }
 func (b *Base) SetName(n string) { b.name = n }
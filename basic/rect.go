@@ -0,0 +1,14 @@
+package basic
+
+// Rect describes axis-aligned rectangle by its top left corner and size.
+type Rect struct {
+	X      float32
+	Y      float32
+	Width  float32
+	Height float32
+}
+
+// Contains returns true if point is inside the rectangle.
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.X && p.X <= r.X+r.Width && p.Y >= r.Y && p.Y <= r.Y+r.Height
+}
@@ -0,0 +1,98 @@
+package basic
+
+import "math"
+
+// Transform is 2D affine transformation matrix
+//
+//	| A C X |
+//	| B D Y |
+//	| 0 0 1 |
+//
+// Rotation angles are in degrees, positive angle rotates clockwise on screen (Y axis points down).
+type Transform struct {
+	A, B, C, D float32
+	X, Y       float32
+}
+
+// IdentityTransform returns transform, which does not change points.
+func IdentityTransform() Transform {
+	return Transform{A: 1, D: 1}
+}
+
+// TranslationTransform returns transform, which moves points by x and y.
+func TranslationTransform(x float32, y float32) Transform {
+	return Transform{A: 1, D: 1, X: x, Y: y}
+}
+
+// RotationTransform returns transform, which rotates points around origin by angle in degrees.
+func RotationTransform(degrees float32) Transform {
+	sin, cos := math.Sincos(float64(degrees) * math.Pi / 180)
+	return Transform{A: float32(cos), B: float32(sin), C: float32(-sin), D: float32(cos)}
+}
+
+// ScaleTransform returns transform, which scales points relative to origin.
+func ScaleTransform(x float32, y float32) Transform {
+	return Transform{A: x, D: y}
+}
+
+// Multiply returns transform, which applies other first, and then t.
+func (t Transform) Multiply(other Transform) Transform {
+	return Transform{
+		A: t.A*other.A + t.C*other.B,
+		B: t.B*other.A + t.D*other.B,
+		C: t.A*other.C + t.C*other.D,
+		D: t.B*other.C + t.D*other.D,
+		X: t.A*other.X + t.C*other.Y + t.X,
+		Y: t.B*other.X + t.D*other.Y + t.Y,
+	}
+}
+
+// Apply transforms point.
+func (t Transform) Apply(p Point) Point {
+	return Point{
+		X: t.A*p.X + t.C*p.Y + t.X,
+		Y: t.B*p.X + t.D*p.Y + t.Y,
+	}
+}
+
+// Inverse returns transform, which reverts t. Returns false if t cannot be inverted (e.g. has zero scale).
+func (t Transform) Inverse() (Transform, bool) {
+	det := t.A*t.D - t.B*t.C
+	if det == 0 {
+		return Transform{}, false
+	}
+
+	return Transform{
+		A: t.D / det,
+		B: -t.B / det,
+		C: -t.C / det,
+		D: t.A / det,
+		X: (t.C*t.Y - t.D*t.X) / det,
+		Y: (t.B*t.X - t.A*t.Y) / det,
+	}, true
+}
+
+// GetTranslation returns point, where origin is moved by transform.
+func (t Transform) GetTranslation() Point {
+	return Point{X: t.X, Y: t.Y}
+}
+
+// GetRotation returns rotation of transform in degrees, assuming there is no skew.
+func (t Transform) GetRotation() float32 {
+	return float32(math.Atan2(float64(t.B), float64(t.A)) * 180 / math.Pi)
+}
+
+// GetScale returns scale of transform along its rotated axes, assuming there is no skew.
+// Negative Y means transform is mirrored.
+func (t Transform) GetScale() Point {
+	sx := float32(math.Hypot(float64(t.A), float64(t.B)))
+	if sx == 0 {
+		return Point{}
+	}
+	return Point{X: sx, Y: (t.A*t.D - t.B*t.C) / sx}
+}
+
+// IsAxisAligned returns true if transform does not rotate or skew.
+func (t Transform) IsAxisAligned() bool {
+	return t.B == 0 && t.C == 0
+}
@@ -0,0 +1,19 @@
+package goplayengine
+
+import (
+	"fmt"
+	"image"
+)
+
+// CaptureFrame returns copy of the last rendered frame.
+//
+// In headless mode frame is read from offscreen render target, so it is always available after Step.
+// With a window, content is read back from renderer, and on some drivers may be undefined after
+// frame was presented, so headless mode is preferred for tests.
+func (e *Engine) CaptureFrame() (*image.RGBA, error) {
+	if e.closed {
+		return nil, fmt.Errorf("engine is closed")
+	}
+
+	return e.renderer.ReadPixels()
+}
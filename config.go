@@ -0,0 +1,148 @@
+package goplayengine
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/resource"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// EngineConfig describes settings used to initialize Engine in NewEngineWithConfig.
+// It is recommended to get config from DefaultEngineConfig and override only needed fields.
+type EngineConfig struct {
+	// Title is the title of the window.
+	Title string
+	// Width and Height are the size of the window in pixels.
+	Width  int32
+	Height int32
+
+	// Resizable allows user to resize the window.
+	Resizable bool
+	// Fullscreen makes window to take whole screen, using current desktop resolution.
+	Fullscreen bool
+	// Borderless creates window without decorations.
+	Borderless bool
+
+	// VSync synchronizes presenting of the frame with refresh rate of the monitor.
+	VSync bool
+	// RendererDriver is a name of SDL render driver to use (e.g. "opengl", "opengles2", "direct3d", "software"),
+	// empty string lets SDL choose the best available driver.
+	RendererDriver string
+
+	// TargetFPS limits number of frames rendered per second, 0 means no limit.
+	TargetFPS int
+
+	// FixedTickRate is a number of fixed updates per second of simulated time, 0 disables fixed timestep.
+	// See core.Scene.SetFixedUpdateFunction.
+	FixedTickRate float64
+	// MaxFixedStepsPerFrame limits number of fixed updates in one frame, when simulation cannot catch up
+	// with real time, remaining time is dropped.
+	MaxFixedStepsPerFrame int
+
+	// Icon is an image used as window icon, nil keeps default icon.
+	Icon *resource.Image
+
+	// InitFlags are passed to sdl.Init.
+	InitFlags uint32
+
+	// Headless makes engine render into offscreen surface of Width x Height instead of a window.
+	// Video subsystem is not initialized in this mode, so it works without display.
+	// See Engine.Step.
+	Headless bool
+
+	// Backend selects implementation of render.Renderer, RendererBackendSoftware can be used only in headless mode.
+	Backend RendererBackend
+}
+
+// RendererBackend selects implementation of render.Renderer used by Engine.
+type RendererBackend uint32
+
+const (
+	// RendererBackendSDL renders with render.SDLRenderer.
+	RendererBackendSDL RendererBackend = iota
+	// RendererBackendSoftware renders with software.Renderer (package render/software).
+	RendererBackendSoftware RendererBackend = iota
+)
+
+// DefaultEngineConfig returns configuration used by NewEngine.
+func DefaultEngineConfig() EngineConfig {
+	return EngineConfig{
+		Title:                 "GAME",
+		Width:                 720,
+		Height:                480,
+		Resizable:             false,
+		Fullscreen:            false,
+		Borderless:            false,
+		VSync:                 true,
+		RendererDriver:        "",
+		TargetFPS:             0,
+		FixedTickRate:         60,
+		MaxFixedStepsPerFrame: 5,
+		Icon:                  nil,
+		InitFlags:             sdl.INIT_EVERYTHING,
+		Headless:              false,
+		Backend:               RendererBackendSDL,
+	}
+}
+
+// validate returns error describing first invalid field of config, nil if config is valid.
+func (c *EngineConfig) validate() error {
+	if c.Width <= 0 || c.Height <= 0 {
+		return fmt.Errorf("invalid window size %dx%d", c.Width, c.Height)
+	}
+	if c.TargetFPS < 0 {
+		return fmt.Errorf("invalid target fps %d", c.TargetFPS)
+	}
+	if c.FixedTickRate < 0 {
+		return fmt.Errorf("invalid fixed tick rate %f", c.FixedTickRate)
+	}
+	if c.FixedTickRate > 0 && c.MaxFixedStepsPerFrame <= 0 {
+		return fmt.Errorf("invalid max fixed steps per frame %d", c.MaxFixedStepsPerFrame)
+	}
+	return nil
+}
+
+// windowFlags converts config into flags for sdl.CreateWindow.
+func (c *EngineConfig) windowFlags() uint32 {
+	var flags uint32 = sdl.WINDOW_SHOWN
+	if c.Resizable {
+		flags |= sdl.WINDOW_RESIZABLE
+	}
+	if c.Fullscreen {
+		flags |= sdl.WINDOW_FULLSCREEN_DESKTOP
+	}
+	if c.Borderless {
+		flags |= sdl.WINDOW_BORDERLESS
+	}
+	return flags
+}
+
+// rendererIndexAndFlags converts config into index and flags for sdl.CreateRenderer.
+func (c *EngineConfig) rendererIndexAndFlags() (int, uint32, error) {
+	var flags uint32 = sdl.RENDERER_ACCELERATED
+	if c.RendererDriver == "software" {
+		flags = sdl.RENDERER_SOFTWARE
+	}
+	if c.VSync {
+		flags |= sdl.RENDERER_PRESENTVSYNC
+	}
+
+	if c.RendererDriver == "" {
+		return -1, flags, nil
+	}
+
+	n, err := sdl.GetNumRenderDrivers()
+	if err != nil {
+		return -1, flags, err
+	}
+	for i := 0; i < n; i++ {
+		var info sdl.RendererInfo
+		if _, err := sdl.GetRenderDriverInfo(i, &info); err != nil {
+			continue
+		}
+		if info.Name == c.RendererDriver {
+			return i, flags, nil
+		}
+	}
+
+	return -1, flags, fmt.Errorf("render driver %q is not available", c.RendererDriver)
+}
@@ -0,0 +1,306 @@
+package core
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/resource"
+)
+
+// AnimationMode determines what happens when animation clip reaches its last frame.
+type AnimationMode uint32
+
+const (
+	// AnimationLoop starts clip from the first frame again.
+	AnimationLoop AnimationMode = iota
+	// AnimationPingPong plays clip backwards to the first frame, and then forward again.
+	AnimationPingPong
+	// AnimationOnce stops on the last frame and calls OnFinish callback.
+	AnimationOnce
+)
+
+// AnimationFrame is a single frame of animation clip.
+type AnimationFrame struct {
+	Image *resource.Image
+	// Region of the image, whole image if nil.
+	Region *basic.Rect
+	// Duration of the frame in seconds.
+	Duration float64
+}
+
+// AnimationClip is a sequence of frames played by AnimatedSprite.
+type AnimationClip struct {
+	Frames []AnimationFrame
+	Mode   AnimationMode
+}
+
+// NewClipFromImages creates clip where every frame is a separate image shown for frameDuration seconds.
+func NewClipFromImages(images []*resource.Image, frameDuration float64, mode AnimationMode) *AnimationClip {
+	clip := &AnimationClip{Mode: mode}
+	for _, image := range images {
+		clip.Frames = append(clip.Frames, AnimationFrame{Image: image, Duration: frameDuration})
+	}
+	return clip
+}
+
+// NewClipFromSpriteSheet creates clip from regions of sprite sheet with indexes from first to last (inclusive),
+// e.g. cells of grid sprite sheet. Duration of region is used if provided by sprite sheet, frameDuration otherwise.
+func NewClipFromSpriteSheet(sheet *resource.SpriteSheet, first int, last int, frameDuration float64, mode AnimationMode) (*AnimationClip, error) {
+	if first < 0 || last >= sheet.Len() || first > last {
+		return nil, fmt.Errorf("invalid range of regions %d..%d, sprite sheet has %d regions", first, last, sheet.Len())
+	}
+
+	clip := &AnimationClip{Mode: mode}
+	for i := first; i <= last; i++ {
+		region, _ := sheet.GetRegion(i)
+
+		duration := region.Duration
+		if duration <= 0 {
+			duration = frameDuration
+		}
+		clip.Frames = append(clip.Frames, AnimationFrame{Image: sheet.GetImage(), Region: &region.Rect, Duration: duration})
+	}
+	return clip, nil
+}
+
+// NewClipFromTag creates clip from Aseprite frame tag of sprite sheet, mode and order of frames are taken from
+// direction of the tag. Duration of region is used if provided by sprite sheet, frameDuration otherwise.
+func NewClipFromTag(sheet *resource.SpriteSheet, tagName string, frameDuration float64) (*AnimationClip, error) {
+	tag, ok := sheet.GetTag(tagName)
+	if !ok {
+		return nil, fmt.Errorf("sprite sheet has no tag %q", tagName)
+	}
+
+	mode := AnimationLoop
+	if tag.Direction == "pingpong" {
+		mode = AnimationPingPong
+	}
+
+	clip, err := NewClipFromSpriteSheet(sheet, tag.From, tag.To, frameDuration, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.Direction == "reverse" {
+		for i, j := 0, len(clip.Frames)-1; i < j; i, j = i+1, j-1 {
+			clip.Frames[i], clip.Frames[j] = clip.Frames[j], clip.Frames[i]
+		}
+	}
+	return clip, nil
+}
+
+// AnimatedSprite plays named animation clips by changing its texture, it is attached to object node
+// with Node.SetAnimatedSprite or created together with node by NewAnimatedNode.
+// Sprites of nodes of active scene are advanced by engine every frame.
+// AnimatedSprite have to be initialized with NewAnimatedSprite.
+type AnimatedSprite struct {
+	texture *Texture
+
+	clips       map[string]*AnimationClip
+	currentName string
+	current     *AnimationClip
+
+	frame     int
+	direction int
+	elapsed   float64
+	speed     float64
+	playing   bool
+
+	onFinish func(clipName string)
+	onFrame  map[string]map[int]func()
+}
+
+// NewAnimatedSprite creates sprite without clips.
+func NewAnimatedSprite() *AnimatedSprite {
+	return &AnimatedSprite{
+		texture:   &Texture{},
+		clips:     make(map[string]*AnimationClip),
+		direction: 1,
+		speed:     1,
+		onFrame:   make(map[string]map[int]func()),
+	}
+}
+
+// NewAnimatedNode creates object node showing sprite.
+func NewAnimatedNode(sprite *AnimatedSprite) *Node {
+	n := NewObjectNode(sprite.GetTexture())
+	n.animatedSprite = sprite
+	return n
+}
+
+// AddClip adds clip with name, replacing clip with the same name.
+// First frame of the first added clip is shown until some clip is played.
+func (s *AnimatedSprite) AddClip(name string, clip *AnimationClip) {
+	s.clips[name] = clip
+	if (s.current == nil || s.currentName == name) && len(clip.Frames) > 0 {
+		s.currentName = name
+		s.current = clip
+		s.direction = 1
+		s.elapsed = 0
+		s.setFrame(0)
+	}
+}
+
+// GetClip returns clip by name, nil if there is no such clip.
+func (s *AnimatedSprite) GetClip(name string) *AnimationClip {
+	return s.clips[name]
+}
+
+// Play starts playing clip from its first frame, if clip is already playing it continues.
+func (s *AnimatedSprite) Play(name string) error {
+	if s.currentName == name && s.playing {
+		return nil
+	}
+	return s.Restart(name)
+}
+
+// Restart starts playing clip from its first frame, even if it is already playing.
+func (s *AnimatedSprite) Restart(name string) error {
+	clip, ok := s.clips[name]
+	if !ok {
+		return fmt.Errorf("animated sprite has no clip %q", name)
+	}
+	if len(clip.Frames) == 0 {
+		return fmt.Errorf("clip %q has no frames", name)
+	}
+
+	s.currentName = name
+	s.current = clip
+	s.direction = 1
+	s.elapsed = 0
+	s.playing = true
+	s.setFrame(0)
+	return nil
+}
+
+// Pause stops advancing frames, current frame stays visible.
+func (s *AnimatedSprite) Pause() {
+	s.playing = false
+}
+
+// Resume continues playing current clip after Pause.
+func (s *AnimatedSprite) Resume() {
+	if s.current != nil {
+		s.playing = true
+	}
+}
+
+// IsPlaying returns true if clip is playing, false if sprite is paused or clip played once has finished.
+func (s *AnimatedSprite) IsPlaying() bool {
+	return s.playing
+}
+
+// GetCurrentClip returns name of current clip, empty if no clip was played.
+func (s *AnimatedSprite) GetCurrentClip() string {
+	return s.currentName
+}
+
+// GetFrame returns index of current frame in current clip.
+func (s *AnimatedSprite) GetFrame() int {
+	return s.frame
+}
+
+// SetSpeed sets playback speed, 1 by default, 2 plays twice as fast.
+func (s *AnimatedSprite) SetSpeed(speed float64) {
+	if speed < 0 {
+		speed = 0
+	}
+	s.speed = speed
+}
+
+// GetSpeed returns playback speed.
+func (s *AnimatedSprite) GetSpeed() float64 {
+	return s.speed
+}
+
+// SetOnFinish sets function called when clip in AnimationOnce mode reaches its end.
+func (s *AnimatedSprite) SetOnFinish(onFinish func(clipName string)) {
+	s.onFinish = onFinish
+}
+
+// SetOnFrame sets function called every time frame with index becomes current frame of the clip, nil removes function.
+func (s *AnimatedSprite) SetOnFrame(clipName string, frame int, onFrame func()) {
+	if onFrame == nil {
+		delete(s.onFrame[clipName], frame)
+		return
+	}
+
+	if s.onFrame[clipName] == nil {
+		s.onFrame[clipName] = make(map[int]func())
+	}
+	s.onFrame[clipName][frame] = onFrame
+}
+
+// Clone returns copy of the sprite with the same clips and playback state.
+// Clips are shared, callbacks are not copied.
+func (s *AnimatedSprite) Clone() *AnimatedSprite {
+	c := *s
+	c.texture = s.texture.Clone()
+	c.clips = make(map[string]*AnimationClip, len(s.clips))
+	for name, clip := range s.clips {
+		c.clips[name] = clip
+	}
+	c.onFinish = nil
+	c.onFrame = make(map[string]map[int]func())
+	return &c
+}
+
+// GetTexture returns texture showing current frame.
+func (s *AnimatedSprite) GetTexture() *Texture {
+	return s.texture
+}
+
+// Update is an internal function, which advances animation by dt seconds.
+func (s *AnimatedSprite) Update(dt float64) {
+	if !s.playing || s.current == nil {
+		return
+	}
+
+	s.elapsed += dt * s.speed
+
+	// number of steps is limited, so frames with zero duration do not hang the loop
+	for steps := 0; s.playing && steps < len(s.current.Frames)*2; steps++ {
+		duration := s.current.Frames[s.frame].Duration
+		if s.elapsed < duration {
+			break
+		}
+		s.elapsed -= duration
+		s.advance()
+	}
+}
+
+// advance switches to the next frame according to mode of current clip.
+func (s *AnimatedSprite) advance() {
+	count := len(s.current.Frames)
+	next := s.frame + s.direction
+
+	if next < 0 || next >= count {
+		switch s.current.Mode {
+		case AnimationLoop:
+			next = 0
+		case AnimationPingPong:
+			s.direction = -s.direction
+			next = max(0, min(count-1, s.frame+s.direction))
+		case AnimationOnce:
+			s.playing = false
+			s.elapsed = 0
+			if s.onFinish != nil {
+				s.onFinish(s.currentName)
+			}
+			return
+		}
+	}
+
+	s.setFrame(next)
+}
+
+// setFrame shows frame of current clip and calls its callback.
+func (s *AnimatedSprite) setFrame(frame int) {
+	s.frame = frame
+	f := s.current.Frames[frame]
+	s.texture.image = f.Image
+	s.texture.SetRegion(f.Region)
+
+	if onFrame := s.onFrame[s.currentName][frame]; onFrame != nil {
+		onFrame()
+	}
+}
@@ -0,0 +1,236 @@
+package core
+
+import (
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"math"
+	"math/rand"
+)
+
+// Camera describes which part of the world is rendered and where on the screen.
+// Camera have to be initialized with NewCamera and attached to Scene with Scene.AddCamera,
+// scene without cameras is rendered in window pixel space.
+type Camera struct {
+	basic.Base
+
+	position basic.Point
+	zoom     float32
+	rotation float32
+
+	// viewport in parts of screen size
+	viewport basic.Rect
+	// last known screen size, updated by engine every frame
+	screenSize basic.Size
+
+	bounds *basic.Rect
+
+	// follow
+	target       *Node
+	targetOffset basic.Point
+	followSpeed  float32
+
+	// shake
+	shakeIntensity float32
+	shakeDuration  float64
+	shakeRemaining float64
+	shakeOffset    basic.Point
+	random         *rand.Rand
+}
+
+// NewCamera creates camera looking at {0, 0} with zoom 1, which renders to whole screen.
+func NewCamera() *Camera {
+	base := basic.MakeBase()
+	return &Camera{
+		Base:     base,
+		zoom:     1,
+		viewport: basic.Rect{X: 0, Y: 0, Width: 1, Height: 1},
+		random:   rand.New(rand.NewSource(int64(base.GetID()))),
+	}
+}
+
+// GetPosition returns point in world space, shown in the center of viewport.
+func (c *Camera) GetPosition() basic.Point {
+	return c.position
+}
+
+// SetPosition sets point in world space, shown in the center of viewport.
+func (c *Camera) SetPosition(position basic.Point) {
+	c.position = position
+	c.clampToBounds()
+}
+
+// GetZoom returns zoom of the camera.
+func (c *Camera) GetZoom() float32 {
+	return c.zoom
+}
+
+// SetZoom sets zoom of the camera, values greater than 1 make objects look bigger, 1 by default.
+func (c *Camera) SetZoom(zoom float32) {
+	if zoom <= 0 {
+		return
+	}
+	c.zoom = zoom
+	c.clampToBounds()
+}
+
+// GetRotation returns rotation of the camera in degrees.
+func (c *Camera) GetRotation() float32 {
+	return c.rotation
+}
+
+// SetRotation sets rotation of the camera in degrees, positive angle rotates view clockwise,
+// so world looks rotated counterclockwise.
+func (c *Camera) SetRotation(degrees float32) {
+	c.rotation = degrees
+}
+
+// GetViewport returns part of the screen camera renders to.
+func (c *Camera) GetViewport() basic.Rect {
+	return c.viewport
+}
+
+// SetViewport sets part of the screen camera renders to, in parts of screen size:
+// {0, 0, 1, 1} is whole screen (default), {0, 0, 0.5, 1} is left half of the screen.
+func (c *Camera) SetViewport(viewport basic.Rect) {
+	c.viewport = viewport
+	c.clampToBounds()
+}
+
+// GetViewportRect returns part of the screen camera renders to, in pixels.
+func (c *Camera) GetViewportRect() basic.Rect {
+	return basic.Rect{
+		X:      c.viewport.X * c.screenSize.Width,
+		Y:      c.viewport.Y * c.screenSize.Height,
+		Width:  c.viewport.Width * c.screenSize.Width,
+		Height: c.viewport.Height * c.screenSize.Height,
+	}
+}
+
+// SetBounds limits camera position, so camera never shows anything outside of bounds in world space
+// (rotation of camera is not taken into account). Nil removes limits.
+func (c *Camera) SetBounds(bounds *basic.Rect) {
+	c.bounds = bounds
+	c.clampToBounds()
+}
+
+// GetBounds returns limits of the camera position, nil if there are no limits.
+func (c *Camera) GetBounds() *basic.Rect {
+	return c.bounds
+}
+
+// Follow makes camera follow target node every frame, offset is added to target position.
+// Speed describes how fast camera reaches target, 0 moves camera to target immediately,
+// greater values make camera catch up faster. Nil target stops following.
+func (c *Camera) Follow(target *Node, offset basic.Point, speed float32) {
+	c.target = target
+	c.targetOffset = offset
+	c.followSpeed = speed
+}
+
+// GetTarget returns node camera follows, nil if camera does not follow any node.
+func (c *Camera) GetTarget() *Node {
+	return c.target
+}
+
+// Shake shakes camera for duration seconds, moving it randomly up to intensity world units,
+// shaking fades out over time.
+func (c *Camera) Shake(intensity float32, duration float64) {
+	c.shakeIntensity = intensity
+	c.shakeDuration = duration
+	c.shakeRemaining = duration
+}
+
+// SetScreenSize is an internal function, which sets size of the screen viewport is relative to.
+func (c *Camera) SetScreenSize(screenSize basic.Size) {
+	c.screenSize = screenSize
+}
+
+// Update is an internal function, which moves camera to its target and updates shaking.
+func (c *Camera) Update(dt float64) {
+	if c.target != nil {
+		pos := c.target.GetAbsolutePosition()
+		target := basic.Point{X: pos.X + c.targetOffset.X, Y: pos.Y + c.targetOffset.Y}
+
+		if c.followSpeed <= 0 {
+			c.position = target
+		} else {
+			k := float32(1 - math.Exp(-float64(c.followSpeed)*dt))
+			c.position.X += (target.X - c.position.X) * k
+			c.position.Y += (target.Y - c.position.Y) * k
+		}
+	}
+	c.clampToBounds()
+
+	c.shakeOffset = basic.Point{}
+	if c.shakeRemaining > 0 {
+		c.shakeRemaining -= dt
+		if c.shakeRemaining > 0 {
+			strength := c.shakeIntensity * float32(c.shakeRemaining/c.shakeDuration)
+			c.shakeOffset = basic.Point{
+				X: (c.random.Float32()*2 - 1) * strength,
+				Y: (c.random.Float32()*2 - 1) * strength,
+			}
+		}
+	}
+}
+
+// GetViewTransform returns transform from world space to screen space.
+func (c *Camera) GetViewTransform() basic.Transform {
+	viewport := c.GetViewportRect()
+
+	return basic.TranslationTransform(viewport.X+viewport.Width/2, viewport.Y+viewport.Height/2).
+		Multiply(basic.ScaleTransform(c.zoom, c.zoom)).
+		Multiply(basic.RotationTransform(-c.rotation)).
+		Multiply(basic.TranslationTransform(-(c.position.X + c.shakeOffset.X), -(c.position.Y + c.shakeOffset.Y)))
+}
+
+// WorldToScreen converts point in world space to screen (window) space.
+func (c *Camera) WorldToScreen(p basic.Point) basic.Point {
+	return c.GetViewTransform().Apply(p)
+}
+
+// ScreenToWorld converts point in screen (window) space, e.g. mouse position, to world space.
+func (c *Camera) ScreenToWorld(p basic.Point) basic.Point {
+	inv, ok := c.GetViewTransform().Inverse()
+	if !ok {
+		return basic.Point{}
+	}
+	return inv.Apply(p)
+}
+
+// ViewportContains returns true if point in screen space is inside viewport of the camera.
+func (c *Camera) ViewportContains(p basic.Point) bool {
+	return c.GetViewportRect().Contains(p)
+}
+
+// GetMouseWorldPosition returns position of the mouse in world space, as seen by this camera.
+func (c *Camera) GetMouseWorldPosition(m PointerInterface) basic.Point {
+	return c.ScreenToWorld(m.GetPosition())
+}
+
+// MouseOver returns true if mouse is inside viewport of the camera and over overlap in world space.
+func (c *Camera) MouseOver(m PointerInterface, overlap OverlapInterface) bool {
+	pos := m.GetPosition()
+	return c.ViewportContains(pos) && overlap.ContainsPoint(c.ScreenToWorld(pos))
+}
+
+// clampToBounds moves camera position, so visible area is inside bounds.
+func (c *Camera) clampToBounds() {
+	if c.bounds == nil || c.screenSize.Width == 0 || c.screenSize.Height == 0 {
+		return
+	}
+
+	viewport := c.GetViewportRect()
+	halfW := viewport.Width / c.zoom / 2
+	halfH := viewport.Height / c.zoom / 2
+
+	c.position.X = clampAxis(c.position.X, c.bounds.X+halfW, c.bounds.X+c.bounds.Width-halfW)
+	c.position.Y = clampAxis(c.position.Y, c.bounds.Y+halfH, c.bounds.Y+c.bounds.Height-halfH)
+}
+
+// clampAxis clamps value to [lo, hi], when range is empty (visible area is bigger than bounds) returns its middle.
+func clampAxis(value float32, lo float32, hi float32) float32 {
+	if lo > hi {
+		return (lo + hi) / 2
+	}
+	return min(max(value, lo), hi)
+}
@@ -0,0 +1,115 @@
+package core
+
+// Component is a behaviour attached to a Node with Node.AddComponent.
+// Components of nodes of the active scene are updated by engine every frame, after scene update function,
+// in hierarchy order: parent node before its children, sibling nodes in order of creation,
+// components of one node in order they were added.
+type Component interface {
+	// Start is called before first Update, ctx gives access to node, scene and input devices.
+	Start(ctx *ComponentContext)
+	// Update is called every frame with time passed since previous frame in seconds.
+	Update(dt float64)
+	// OnDestroy is called when started component is removed from node, or node is removed from scene
+	// (with Scene.RemoveNode, or RemoveChild of its ancestor attached to scene).
+	OnDestroy()
+}
+
+// ComponentContext gives component access to its node, scene and input devices.
+type ComponentContext struct {
+	node   *Node
+	scene  *Scene
+	inputs *InputDevices
+}
+
+// GetNode returns node component is attached to.
+func (c *ComponentContext) GetNode() *Node {
+	return c.node
+}
+
+// GetScene returns scene component was started in.
+func (c *ComponentContext) GetScene() *Scene {
+	return c.scene
+}
+
+type componentEntry struct {
+	component Component
+	// ctx is nil until component is started
+	ctx     *ComponentContext
+	removed bool
+}
+
+// AddComponent attaches component to the node, component is started before its first update.
+func (n *Node) AddComponent(component Component) {
+	n.components = append(n.components, &componentEntry{component: component})
+}
+
+// RemoveComponent detaches component from the node, calling its OnDestroy if it was started.
+// Returns false if component is not attached to the node.
+func (n *Node) RemoveComponent(component Component) bool {
+	for i, entry := range n.components {
+		if entry.component == component {
+			n.components = append(n.components[:i], n.components[i+1:]...)
+			entry.removed = true
+			if entry.ctx != nil {
+				entry.component.OnDestroy()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// GetComponents returns all components attached to the node.
+func (n *Node) GetComponents() []Component {
+	result := make([]Component, 0, len(n.components))
+	for _, entry := range n.components {
+		result = append(result, entry.component)
+	}
+	return result
+}
+
+// GetComponent returns first component of type T attached to the node, false if there is no such component.
+func GetComponent[T Component](n *Node) (T, bool) {
+	for _, entry := range n.components {
+		if c, ok := entry.component.(T); ok {
+			return c, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// UpdateComponents is an internal function, which starts new components of the node and updates all of them.
+func (n *Node) UpdateComponents(scene *Scene, inputs *InputDevices, dt float64) {
+	// components may be added or removed by other components
+	entries := append([]*componentEntry(nil), n.components...)
+	for _, entry := range entries {
+		if entry.removed {
+			continue
+		}
+
+		if entry.ctx == nil {
+			entry.ctx = &ComponentContext{node: n, scene: scene, inputs: inputs}
+			entry.component.Start(entry.ctx)
+			if entry.removed {
+				continue
+			}
+		}
+		entry.component.Update(dt)
+	}
+}
+
+// destroyComponents calls OnDestroy of started components of the node and its children,
+// components are started again if node is added to scene later.
+func (n *Node) destroyComponents() {
+	for _, entry := range n.components {
+		if entry.ctx != nil {
+			entry.ctx = nil
+			entry.component.OnDestroy()
+		}
+	}
+	for _, child := range n.GetChildren() {
+		child.destroyComponents()
+	}
+}
@@ -0,0 +1,39 @@
+//go:build cgo
+
+package core
+
+import "github.com/SemyonHoyrish/GoPlayEngine/input"
+
+// InputDevices is a set of input devices of the engine, which is given to components.
+type InputDevices struct {
+	Mouse     *input.Mouse
+	Keyboard  *input.Keyboard
+	Gamepads  *input.Gamepads
+	Actions   *input.ActionMap
+	TextInput *input.TextInput
+}
+
+// GetMouse returns mouse of the engine.
+func (c *ComponentContext) GetMouse() *input.Mouse {
+	return c.inputs.Mouse
+}
+
+// GetKeyboard returns keyboard of the engine.
+func (c *ComponentContext) GetKeyboard() *input.Keyboard {
+	return c.inputs.Keyboard
+}
+
+// GetGamepads returns gamepads of the engine.
+func (c *ComponentContext) GetGamepads() *input.Gamepads {
+	return c.inputs.Gamepads
+}
+
+// GetActionMap returns action map of the engine.
+func (c *ComponentContext) GetActionMap() *input.ActionMap {
+	return c.inputs.Actions
+}
+
+// GetTextInput returns text input of the engine.
+func (c *ComponentContext) GetTextInput() *input.TextInput {
+	return c.inputs.TextInput
+}
@@ -0,0 +1,7 @@
+//go:build !cgo
+
+package core
+
+// InputDevices is a set of input devices of the engine, which is given to components.
+// Input devices are implemented with SDL, so without cgo the set is empty and components have no access to input.
+type InputDevices struct{}
@@ -3,7 +3,6 @@ package core
 import (
 	"fmt"
 	"github.com/SemyonHoyrish/GoPlayEngine/basic"
-	"github.com/SemyonHoyrish/GoPlayEngine/input"
 )
 
 // ComposedOverlap represent area, consisting of areas defined by other OverlapInterfaces, that can be overlapped with
@@ -24,6 +23,15 @@ func NewComposedOverlap() *ComposedOverlap {
 	}
 }
 
+// Clone returns copy of the composition with copies of its Overlaps, not attached to any node.
+func (co *ComposedOverlap) Clone() *ComposedOverlap {
+	c := NewComposedOverlap()
+	for _, o := range co.overlaps {
+		c.Add(o.Clone())
+	}
+	return c
+}
+
 // SetNode internal function that links node and overlap. Should NOT be called by user.
 func (co *ComposedOverlap) SetNode(node *Node) bool {
 	if node == nil {
@@ -53,6 +61,11 @@ func (co *ComposedOverlap) Add(overlap *Overlap) {
 	overlap.SetComposedOverlap(co)
 }
 
+// GetOverlaps returns Overlaps of composition.
+func (co *ComposedOverlap) GetOverlaps() []*Overlap {
+	return co.overlaps
+}
+
 // OverlapsWith returns true if any of underlying Overlaps overlapping with a target.
 // In case `other` also a ComposedOverlap, every pair is checked.
 func (co *ComposedOverlap) OverlapsWith(other OverlapInterface) bool {
@@ -83,7 +96,7 @@ func (co *ComposedOverlap) OverlapsWith(other OverlapInterface) bool {
 }
 
 // MouseOver return true if any of underlying Overlaps is hovered by Mouse.
-func (co *ComposedOverlap) MouseOver(m *input.Mouse) bool {
+func (co *ComposedOverlap) MouseOver(m PointerInterface) bool {
 	for _, over := range co.overlaps {
 		if over.MouseOver(m) {
 			return true
@@ -91,3 +104,13 @@ func (co *ComposedOverlap) MouseOver(m *input.Mouse) bool {
 	}
 	return false
 }
+
+// ContainsPoint returns true if point in world space is inside any of underlying Overlaps.
+func (co *ComposedOverlap) ContainsPoint(p basic.Point) bool {
+	for _, over := range co.overlaps {
+		if over.ContainsPoint(p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+package core
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"github.com/SemyonHoyrish/GoPlayEngine/render"
+	"math"
+	"sort"
+)
+
+// DrawScene draws nodes of the scene and sprites of its worlds with renderer, moved by offset,
+// once by every camera of the scene, clipped to its viewport. Background of the scene is not drawn.
+// Engine draws scenes with DrawScene, it can be used to draw scene without engine, e.g. by software renderer in tests.
+func DrawScene(r render.Renderer, scene *Scene, offset basic.Point) {
+	offsetTransform := basic.TranslationTransform(offset.X, offset.Y)
+
+	var sprites []Sprite
+	for _, world := range scene.GetWorlds() {
+		sprites = world.AppendSprites(sprites)
+	}
+	sort.SliceStable(sprites, func(i, j int) bool {
+		return sprites[i].Layer < sprites[j].Layer
+	})
+
+	cameras := scene.GetCameras()
+	if len(cameras) == 0 {
+		drawWithSprites(r, scene.GetAllNodes(), sprites, offsetTransform)
+		return
+	}
+
+	size := r.GetOutputSize()
+	for _, camera := range cameras {
+		camera.SetScreenSize(size)
+
+		viewport := camera.GetViewportRect()
+		viewport.X += offset.X
+		viewport.Y += offset.Y
+		r.SetClip(&viewport)
+
+		drawWithSprites(r, scene.GetAllNodes(), sprites, offsetTransform.Multiply(camera.GetViewTransform()))
+	}
+	r.SetClip(nil)
+}
+
+// DrawNodes draws nodes and their children with renderer, parent is a transform from space of nodes
+// to render target (basic.IdentityTransform for nodes of scene without camera).
+func DrawNodes(r render.Renderer, nodes []*Node, parent basic.Transform) {
+	drawNodes(r, nodes, parent, 1)
+}
+
+// drawWithSprites draws nodes of scene and sprites of its worlds sorted by layer, sprites are drawn after nodes
+// of the same layer. Sprites have to be sorted by layer already.
+func drawWithSprites(r render.Renderer, nodes []*Node, sprites []Sprite, parent basic.Transform) {
+	if len(sprites) == 0 {
+		drawNodes(r, nodes, parent, 1)
+		return
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].GetLayer() < nodes[j].GetLayer()
+	})
+
+	n := 0
+	for _, sprite := range sprites {
+		start := n
+		for n < len(nodes) && nodes[n].GetLayer() <= sprite.Layer {
+			n++
+		}
+		if n > start {
+			drawNodes(r, nodes[start:n], parent, 1)
+		}
+
+		drawTexture(r, sprite.Texture, sprite.Bounds, parent.Multiply(sprite.Transform), sprite.Alpha, sprite.ID)
+	}
+	if n < len(nodes) {
+		drawNodes(r, nodes[n:], parent, 1)
+	}
+}
+
+// drawNodes draws nodes and their children, parent is a transform from space of nodes to the screen,
+// alpha is opacity of parent node.
+func drawNodes(r render.Renderer, nodes []*Node, parent basic.Transform, parentAlpha float32) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].GetLayer() < nodes[j].GetLayer()
+	})
+
+	for _, node := range nodes {
+		if node.AutoOverlapEnabled() {
+			node.BuildAutoOverlap(false, nil, nil)
+		}
+
+		alpha := parentAlpha * node.GetAlpha()
+		if alpha <= 0 {
+			continue
+		}
+
+		world := parent.Multiply(node.GetLocalTransform())
+
+		switch node.GetType() {
+		case NodeTypeObject:
+			if t := node.GetTexture(); t != nil {
+				drawTexture(r, t, node.GetLocalBounds(), world, alpha, node.GetID())
+			}
+
+		case NodeTypeText:
+			textInfo := node.GetTextInfo()
+			dst, transform := textureDestination(node.GetLocalBounds(), world)
+			transform.Transparency = 1 - alpha
+
+			err := r.DrawText(textInfo.Text, textInfo.Font.GetFace(textInfo.TextSize), textInfo.Color, dst, transform)
+			if err != nil {
+				fmt.Println(fmt.Errorf("cannot render text (node id = %d): %v", node.GetID(), err))
+			}
+
+		case NodeTypeBase:
+			// We do not need to do anything when we encounter BaseNode, at least at the moment
+		}
+
+		childNodes := node.GetChildren()
+		drawNodes(r, childNodes, world, alpha)
+	}
+}
+
+// drawTexture draws texture occupying bounds in its own space, world is a transform from that space to the screen.
+// id is an id of node or entity texture belongs to, used in error messages.
+func drawTexture(r render.Renderer, t *Texture, bounds basic.Rect, world basic.Transform, alpha float32, id basic.IDType) {
+	if t.GetPrimitive() != nil {
+		prim := t.GetPrimitive()
+		c := prim.GetColor()
+		c.A = uint8(float32(c.A) * alpha)
+
+		var err error
+		switch prim.GetPrimitiveType() {
+		case primitive.RectanglePrimitive:
+			err = fillRect(r, bounds, world, c)
+		case primitive.CirclePrimitive:
+			if bounds.Width != bounds.Height {
+				fmt.Println(fmt.Errorf("circle width and height differ, possibly trying to override with node size (id = %d)", id))
+				break
+			}
+			err = fillCircle(r, bounds, world, c)
+		case primitive.EllipsePrimitive:
+			panic("TODO implement")
+		case primitive.LinePrimitive:
+			err = r.DrawLine(
+				world.Apply(basic.Point{X: bounds.X, Y: bounds.Y}),
+				world.Apply(basic.Point{X: bounds.X + bounds.Width, Y: bounds.Y + bounds.Height}),
+				c,
+			)
+		}
+		if err != nil {
+			fmt.Println(fmt.Errorf("cannot render primitive (id = %d): %v", id, err))
+		}
+	} else if t.GetImage() != nil {
+		dst, transform := textureDestination(bounds, world)
+		transform.Transparency = 1 - alpha
+		if err := r.DrawTexture(t.GetImage().GetImage(), t.GetRegion(), dst, transform); err != nil {
+			fmt.Println(fmt.Errorf("cannot render image (id = %d): %v", id, err))
+		}
+	} else {
+		fmt.Println(fmt.Errorf("node has empty texture (id = %d)", id))
+	}
+}
+
+// fillRect draws rectangle in node space, rotated rectangles are drawn as polygons.
+func fillRect(r render.Renderer, bounds basic.Rect, world basic.Transform, color primitive.Color) error {
+	corners := []basic.Point{
+		world.Apply(basic.Point{X: bounds.X, Y: bounds.Y}),
+		world.Apply(basic.Point{X: bounds.X + bounds.Width, Y: bounds.Y}),
+		world.Apply(basic.Point{X: bounds.X + bounds.Width, Y: bounds.Y + bounds.Height}),
+		world.Apply(basic.Point{X: bounds.X, Y: bounds.Y + bounds.Height}),
+	}
+
+	if !world.IsAxisAligned() {
+		return r.FillPolygon(corners, color)
+	}
+
+	return r.FillRect(basic.Rect{
+		X:      min(corners[0].X, corners[2].X),
+		Y:      min(corners[0].Y, corners[2].Y),
+		Width:  float32(math.Abs(float64(corners[2].X - corners[0].X))),
+		Height: float32(math.Abs(float64(corners[2].Y - corners[0].Y))),
+	}, color)
+}
+
+// circleSegments is a number of segments of polygon used to draw circle scaled non-uniformly.
+const circleSegments = 32
+
+// fillCircle draws circle inscribed in bounds in node space, non-uniformly scaled circles are drawn as polygons.
+func fillCircle(r render.Renderer, bounds basic.Rect, world basic.Transform, color primitive.Color) error {
+	radius := bounds.Width / 2
+	center := basic.Point{X: bounds.X + radius, Y: bounds.Y + radius}
+	scale := world.GetScale()
+	sx, sy := float32(math.Abs(float64(scale.X))), float32(math.Abs(float64(scale.Y)))
+
+	if math.Abs(float64(sx-sy)) <= 1e-4*float64(sx) {
+		return r.FillCircle(world.Apply(center), radius*sx, color)
+	}
+
+	points := make([]basic.Point, circleSegments)
+	for i := range points {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / circleSegments)
+		points[i] = world.Apply(basic.Point{X: center.X + radius*float32(cos), Y: center.Y + radius*float32(sin)})
+	}
+	return r.FillPolygon(points, color)
+}
+
+// textureDestination converts bounds in node space into destination rectangle and transform of texture on the screen.
+func textureDestination(bounds basic.Rect, world basic.Transform) (basic.Rect, render.TextureTransform) {
+	scale := world.GetScale()
+	angle := world.GetRotation()
+	flipVertical := scale.Y < 0
+	sx, sy := scale.X, float32(math.Abs(float64(scale.Y)))
+
+	// offset of node origin from the top left corner of destination rectangle
+	origin := basic.Point{X: -bounds.X * sx, Y: -bounds.Y * sy}
+	if flipVertical {
+		origin.Y = (bounds.Y + bounds.Height) * sy
+	}
+
+	pos := world.GetTranslation()
+	dst := basic.Rect{
+		X:      pos.X - origin.X,
+		Y:      pos.Y - origin.Y,
+		Width:  bounds.Width * sx,
+		Height: bounds.Height * sy,
+	}
+
+	transform := render.TextureTransform{FlipVertical: flipVertical}
+	if angle != 0 {
+		transform.Angle = angle
+		transform.Center = origin
+	}
+
+	return dst, transform
+}
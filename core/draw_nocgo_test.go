@@ -0,0 +1,29 @@
+//go:build !cgo
+
+package core
+
+import (
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"github.com/SemyonHoyrish/GoPlayEngine/resource"
+	"github.com/SemyonHoyrish/GoPlayEngine/snapshot"
+	"testing"
+)
+
+// Without cgo text is rasterized by Go OpenType rasterizer instead of SDL_ttf, so it has its own golden.
+func TestDrawTextWithoutCgo(t *testing.T) {
+	node := NewTextNode(&NodeTextInfo{
+		Text:     "GoPlay",
+		TextSize: 14,
+		Font:     resource.NewFont("../testdata/assets/DejaVuSansMono.ttf"),
+		Color:    primitive.Color{R: 230, G: 120, B: 40, A: 255},
+	})
+	node.SetPosition(basic.Point{X: 32, Y: 24})
+
+	if size := node.GetCalculatedSize(); size.Width == 0 || size.Height == 0 {
+		t.Fatalf("size of text node is %v", size)
+	}
+
+	frame := drawTestNodes(t, node)
+	snapshot.Assert(t, frame, "../testdata/render/text_software_nocgo.png", snapshot.Options{Tolerance: 2})
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"github.com/SemyonHoyrish/GoPlayEngine/render/software"
+	"github.com/SemyonHoyrish/GoPlayEngine/resource"
+	"github.com/SemyonHoyrish/GoPlayEngine/snapshot"
+	"image"
+	"testing"
+)
+
+// Node trees are drawn by software renderer, which does not need cgo, and compared with goldens of headless engine,
+// so output of the engine is reproduced without it.
+
+var testBackground = primitive.Color{R: 20, G: 20, B: 40, A: 255}
+
+// drawTestNodes draws nodes into 64x48 image in the same way as headless engine with software backend.
+func drawTestNodes(t *testing.T, nodes ...*Node) *image.RGBA {
+	t.Helper()
+
+	r := software.NewRenderer(64, 48)
+	defer r.Destroy()
+
+	scene := NewScene()
+	for _, node := range nodes {
+		scene.AddNode(node)
+	}
+
+	r.Clear(testBackground)
+	DrawScene(r, scene, basic.Point{})
+	r.Present()
+
+	frame, err := r.ReadPixels()
+	if err != nil {
+		t.Fatalf("cannot read pixels: %v", err)
+	}
+	return frame
+}
+
+func newTestNode(texture *Texture) *Node {
+	node := NewObjectNode(texture)
+	node.SetPosition(basic.Point{X: 32, Y: 24})
+	return node
+}
+
+func TestDrawScene(t *testing.T) {
+	cases := []struct {
+		name string
+		node func() *Node
+	}{
+		{"rectangle", func() *Node {
+			return newTestNode(NewTextureFromPrimitive(primitive.Rectangle{Width: 30, Height: 16, Color: primitive.Color{R: 230, G: 40, B: 40, A: 255}}))
+		}},
+		{"circle", func() *Node {
+			return newTestNode(NewTextureFromPrimitive(primitive.Circle{Radius: 12, Color: primitive.Color{R: 40, G: 220, B: 80, A: 255}}))
+		}},
+		{"line", func() *Node {
+			return newTestNode(NewTextureFromPrimitive(primitive.Line{To: basic.Point{X: 40, Y: 24}, Color: primitive.Color{R: 255, G: 255, B: 255, A: 255}}))
+		}},
+		{"image", func() *Node {
+			node := newTestNode(NewTextureFromImage(resource.NewImage("../testdata/assets/gradient.png")))
+			node.SetOverrideSize(basic.Size{Width: 24, Height: 24})
+			return node
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			frame := drawTestNodes(t, c.node())
+			snapshot.Assert(t, frame, fmt.Sprintf("../testdata/render/%s_software.png", c.name), snapshot.Options{Tolerance: 2})
+		})
+	}
+}
+
+func TestDrawNodesHierarchy(t *testing.T) {
+	red := primitive.Color{R: 255, G: 0, B: 0, A: 255}
+	parent := NewObjectNode(NewTextureFromPrimitive(primitive.Rectangle{Width: 10, Height: 10, Color: red}))
+	parent.SetPosition(basic.Point{X: 10, Y: 10})
+	parent.SetAlpha(0.5)
+
+	child := NewObjectNode(NewTextureFromPrimitive(primitive.Rectangle{Width: 4, Height: 4, Color: red}))
+	child.SetPosition(basic.Point{X: 20, Y: 0})
+	parent.AddChild(child)
+
+	r := software.NewRenderer(40, 20)
+	r.Clear(primitive.Color{A: 255})
+	DrawNodes(r, []*Node{parent}, basic.IdentityTransform())
+	r.Present()
+
+	// child is drawn relative to parent and inherits its alpha
+	for _, p := range []image.Point{{10, 10}, {30, 10}} {
+		if c := r.GetImage().RGBAAt(p.X, p.Y); c.R < 126 || c.R > 128 {
+			t.Errorf("pixel %v is %v, expected half transparent red", p, c)
+		}
+	}
+	if c := r.GetImage().RGBAAt(30, 4); c.R != 0 {
+		t.Errorf("pixel outside of child is %v", c)
+	}
+}
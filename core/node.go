@@ -5,7 +5,9 @@ import (
 	"github.com/SemyonHoyrish/GoPlayEngine/basic"
 	"github.com/SemyonHoyrish/GoPlayEngine/data_structures"
 	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"github.com/SemyonHoyrish/GoPlayEngine/render"
 	"github.com/SemyonHoyrish/GoPlayEngine/resource"
+	"sort"
 )
 
 // NodeType is an internal type
@@ -32,14 +34,34 @@ type Node struct {
 	size     basic.Size
 	layer    LayerType
 
+	// transform relative to parent node
+	rotation       float32
+	scale          basic.Point
+	pivot          basic.Point
+	flipHorizontal bool
+	flipVertical   bool
+
+	// opacity of the node and its children
+	alpha float32
+
 	parent   *Node
 	children data_structures.Set[*Node]
 
 	// object node
-	texture *Texture
+	texture        *Texture
+	animatedSprite *AnimatedSprite
 
 	// text node
 	textInfo *NodeTextInfo
+	// measured size of text, valid while text and font are the same as in textSizeKey
+	textSizeKey textSizeKey
+	textSize    basic.Size
+
+	components []*componentEntry
+
+	tags data_structures.Set[string]
+	// index of the scene node belongs to, nil if node is not attached to scene
+	index *nodeIndex
 
 	overlap OverlapInterface
 
@@ -50,6 +72,13 @@ type Node struct {
 	autoOverlapChild   bool
 }
 
+// textSizeKey identifies text measured by node, font face is specific to font size,
+// so changes of any field of NodeTextInfo affecting size change the key.
+type textSizeKey struct {
+	font render.Font
+	text string
+}
+
 type NodeTextInfo struct {
 	Text     string
 	TextSize int
@@ -59,12 +88,16 @@ type NodeTextInfo struct {
 
 func NewNode() *Node {
 	return &Node{
-		Base:     basic.Base{},
+		Base:     basic.MakeBase(),
 		nodeType: NodeTypeBase,
 		position: basic.Point{},
 		size:     basic.Size{},
+		scale:    basic.Point{X: 1, Y: 1},
+		pivot:    basic.Point{X: 0.5, Y: 0.5},
+		alpha:    1,
 		parent:   nil,
 		children: data_structures.CreateSet[*Node](),
+		tags:     data_structures.CreateSet[string](),
 		texture:  nil,
 		textInfo: nil,
 	}
@@ -76,8 +109,12 @@ func NewObjectNode(texture *Texture /* nillable */) *Node {
 		nodeType: NodeTypeObject,
 		position: basic.Point{},
 		size:     basic.Size{},
+		scale:    basic.Point{X: 1, Y: 1},
+		pivot:    basic.Point{X: 0.5, Y: 0.5},
+		alpha:    1,
 		parent:   nil,
 		children: data_structures.CreateSet[*Node](),
+		tags:     data_structures.CreateSet[string](),
 		texture:  texture,
 		textInfo: nil,
 	}
@@ -89,8 +126,12 @@ func NewTextNode(textInfo *NodeTextInfo) *Node {
 		nodeType: NodeTypeText,
 		position: basic.Point{},
 		size:     basic.Size{},
+		scale:    basic.Point{X: 1, Y: 1},
+		pivot:    basic.Point{X: 0.5, Y: 0.5},
+		alpha:    1,
 		parent:   nil,
 		children: data_structures.CreateSet[*Node](),
+		tags:     data_structures.CreateSet[string](),
 		texture:  nil,
 		textInfo: textInfo,
 	}
@@ -108,17 +149,108 @@ func (n *Node) SetPosition(position basic.Point) {
 	n.position = position
 }
 
+// GetAbsolutePosition returns position of the node in world space, taking into account transforms of parent nodes.
 func (n *Node) GetAbsolutePosition() basic.Point {
-	parent := n.parent
+	return n.GetWorldTransform().GetTranslation()
+}
 
-	if parent != nil {
-		return basic.Point{
-			X: n.position.X + parent.GetAbsolutePosition().X,
-			Y: n.position.Y + parent.GetAbsolutePosition().Y,
-		}
+// GetRotation returns rotation of the node relative to parent in degrees.
+func (n *Node) GetRotation() float32 {
+	return n.rotation
+}
+
+// SetRotation sets rotation of the node relative to parent in degrees, positive angle rotates clockwise.
+// Node is rotated around its pivot, children are rotated together with the node.
+func (n *Node) SetRotation(degrees float32) {
+	n.rotation = degrees
+}
+
+// GetScale returns scale of the node relative to parent.
+func (n *Node) GetScale() basic.Point {
+	return n.scale
+}
+
+// SetScale sets scale of the node relative to parent, {1, 1} by default.
+// Node is scaled around its pivot, children are scaled together with the node.
+func (n *Node) SetScale(scale basic.Point) {
+	n.scale = scale
+}
+
+// GetPivot returns pivot of the node.
+func (n *Node) GetPivot() basic.Point {
+	return n.pivot
+}
+
+// SetPivot sets point of the node, which is placed at node position, and around which node is rotated and scaled.
+// Pivot is relative to node size: {0, 0} is top left corner, {1, 1} is bottom right corner,
+// {0.5, 0.5} (center) by default.
+func (n *Node) SetPivot(pivot basic.Point) {
+	n.pivot = pivot
+}
+
+// GetFlipHorizontal returns true if node is mirrored horizontally.
+func (n *Node) GetFlipHorizontal() bool {
+	return n.flipHorizontal
+}
+
+// SetFlipHorizontal mirrors node and its children horizontally around pivot.
+func (n *Node) SetFlipHorizontal(flip bool) {
+	n.flipHorizontal = flip
+}
+
+// GetFlipVertical returns true if node is mirrored vertically.
+func (n *Node) GetFlipVertical() bool {
+	return n.flipVertical
+}
+
+// SetFlipVertical mirrors node and its children vertically around pivot.
+func (n *Node) SetFlipVertical(flip bool) {
+	n.flipVertical = flip
+}
+
+// GetAlpha returns opacity of the node relative to parent.
+func (n *Node) GetAlpha() float32 {
+	return n.alpha
+}
+
+// SetAlpha sets opacity of the node from 0 (invisible) to 1 (opaque, default), regardless of layer.
+// Opacity is multiplied down the hierarchy, so children fade together with the node.
+func (n *Node) SetAlpha(alpha float32) {
+	n.alpha = min(max(alpha, 0), 1)
+}
+
+// GetLocalTransform returns transform from node space to parent space, built from position, rotation, scale and flip.
+func (n *Node) GetLocalTransform() basic.Transform {
+	scale := n.scale
+	if n.flipHorizontal {
+		scale.X = -scale.X
+	}
+	if n.flipVertical {
+		scale.Y = -scale.Y
 	}
 
-	return n.position
+	return basic.TranslationTransform(n.position.X, n.position.Y).
+		Multiply(basic.RotationTransform(n.rotation)).
+		Multiply(basic.ScaleTransform(scale.X, scale.Y))
+}
+
+// GetWorldTransform returns transform from node space to world space.
+func (n *Node) GetWorldTransform() basic.Transform {
+	if n.parent != nil {
+		return n.parent.GetWorldTransform().Multiply(n.GetLocalTransform())
+	}
+	return n.GetLocalTransform()
+}
+
+// GetLocalBounds returns rectangle occupied by the node in node space, based on calculated size and pivot.
+func (n *Node) GetLocalBounds() basic.Rect {
+	size := n.GetCalculatedSize()
+	return basic.Rect{
+		X:      -n.pivot.X * size.Width,
+		Y:      -n.pivot.Y * size.Height,
+		Width:  size.Width,
+		Height: size.Height,
+	}
 }
 
 func (n *Node) GetOverrideSize() basic.Size {
@@ -146,16 +278,25 @@ func (n *Node) GetCalculatedSize() basic.Size {
 	case NodeTypeText:
 		textInfo := n.textInfo
 		font := textInfo.Font
-		ttfFont := font.GetTTFFont(textInfo.TextSize)
+		face := font.GetFace(textInfo.TextSize)
 		size := n.size
 		if size.Width == 0 && size.Height == 0 {
-			w, h, _ := ttfFont.SizeUTF8(textInfo.Text)
-			if w == 0 && h == 0 {
+			key := textSizeKey{font: face, text: textInfo.Text}
+			if face != nil && n.textSizeKey == key {
+				return n.textSize
+			}
+
+			var measured basic.Size
+			if face != nil {
+				measured, _ = face.MeasureText(textInfo.Text)
+			}
+			if measured.Width == 0 && measured.Height == 0 {
 				err := fmt.Errorf("size of node (id=%d) is zero still after resolution", n.GetID())
 				fmt.Println(err)
 			} else {
-				size.Width = float32(w)
-				size.Height = float32(h)
+				size = measured
+				n.textSizeKey = key
+				n.textSize = size
 			}
 		}
 		return size
@@ -183,12 +324,14 @@ func (n *Node) setParent(parent *Node) {
 func (n *Node) AddChild(child *Node) {
 	n.children.Add(child)
 	child.setParent(n)
+	if n.index != nil {
+		n.index.addTree(child)
+	}
 }
 
 func (n *Node) AddChildMany(child ...*Node) {
 	for _, c := range child {
-		n.children.Add(c)
-		c.setParent(n)
+		n.AddChild(c)
 	}
 }
 
@@ -196,6 +339,11 @@ func (n *Node) RemoveChild(child *Node) bool {
 	removed := n.children.Remove(child)
 	if removed {
 		child.setParent(nil)
+		if n.index != nil {
+			// node is attached to scene, so child is removed from scene too
+			n.index.removeTree(child)
+			child.destroyComponents()
+		}
 	}
 
 	return removed
@@ -247,6 +395,11 @@ func (n *Node) AutoOverlapEnabled() bool {
 	return n.autoOverlapEnabled
 }
 
+// IsPartOfAutoOverlap returns true if overlap of the node was built as a part of auto overlap of the node or its parent.
+func (n *Node) IsPartOfAutoOverlap() bool {
+	return n.autoOverlapChild
+}
+
 func (n *Node) GetAutoOverlap() *ComposedOverlap {
 	return n.autoOverlap
 }
@@ -292,14 +445,14 @@ func (n *Node) BuildAutoOverlap(ignoreAutoOverlapSet bool, root *Node, rootOverl
 		isRootNode = true
 	}
 
-	size := n.GetCalculatedSize()
+	bounds := n.GetLocalBounds()
 
 	ov := NewOverlap(
-		basic.Point{-size.Width / 2, -size.Height / 2},
-		basic.Point{+size.Width / 2, +size.Height / 2},
+		basic.Point{X: bounds.X, Y: bounds.Y},
+		basic.Point{X: bounds.X + bounds.Width, Y: bounds.Y + bounds.Height},
 	)
 
-	if size != (basic.Size{0, 0}) {
+	if bounds.Width != 0 || bounds.Height != 0 {
 		n.SetOverlap(ov)
 		rootOverlap.Add(ov)
 	}
@@ -331,11 +484,57 @@ func (n *Node) SetTexture(texture *Texture) error {
 	}
 
 	n.texture = texture
+	n.animatedSprite = nil
+	return nil
+}
+
+// GetAnimatedSprite returns sprite animating texture of the node, nil if node is not animated.
+func (n *Node) GetAnimatedSprite() *AnimatedSprite {
+	return n.animatedSprite
+}
+
+// SetAnimatedSprite sets texture of the node to the texture of sprite, which is advanced by engine every frame.
+// Nil stops animating, keeping current frame.
+func (n *Node) SetAnimatedSprite(sprite *AnimatedSprite) error {
+	if n.nodeType != NodeTypeObject {
+		return fmt.Errorf("cannot set animated sprite on non-object node (id=%d)", n.GetID())
+	}
+
+	n.animatedSprite = sprite
+	if sprite != nil {
+		n.texture = sprite.GetTexture()
+	}
 	return nil
 }
 
 // ---------------
 
+// GetColor returns color of text for text node, or color of primitive for object node with primitive texture,
+// false if node has no color.
+func (n *Node) GetColor() (primitive.Color, bool) {
+	switch {
+	case n.nodeType == NodeTypeText && n.textInfo != nil:
+		return n.textInfo.Color, true
+	case n.nodeType == NodeTypeObject && n.texture != nil && n.texture.GetPrimitive() != nil:
+		return n.texture.GetPrimitive().GetColor(), true
+	}
+	return primitive.Color{}, false
+}
+
+// SetColor sets color of text for text node, or color of primitive for object node with primitive texture.
+// Texture is shared by nodes it was set to, so color of the primitive changes for all of them.
+func (n *Node) SetColor(color primitive.Color) error {
+	switch {
+	case n.nodeType == NodeTypeText && n.textInfo != nil:
+		n.textInfo.Color = color
+		return nil
+	case n.nodeType == NodeTypeObject && n.texture != nil && n.texture.GetPrimitive() != nil:
+		n.texture.SetPrimitive(primitive.WithColor(n.texture.GetPrimitive(), color))
+		return nil
+	}
+	return fmt.Errorf("node has no color (id=%d)", n.GetID())
+}
+
 // --- text node ---
 
 func (n *Node) GetTextInfo() *NodeTextInfo {
@@ -352,3 +551,66 @@ func (n *Node) SetTextInfo(textInfo *NodeTextInfo) error {
 }
 
 // ---------------
+
+// Clone returns deep copy of the node and its children with new IDs, not attached to any parent or scene.
+// Tags, textures, text info and overlaps are copied, so they can be changed for the copy only, images and fonts are shared.
+// Animated sprite is copied without callbacks. Components are not copied, because they keep state of the node.
+// Auto overlap is built again for the copy if it is enabled.
+func (n *Node) Clone() *Node {
+	c := &Node{
+		Base:           basic.MakeBase(),
+		nodeType:       n.nodeType,
+		position:       n.position,
+		size:           n.size,
+		layer:          n.layer,
+		rotation:       n.rotation,
+		scale:          n.scale,
+		pivot:          n.pivot,
+		flipHorizontal: n.flipHorizontal,
+		flipVertical:   n.flipVertical,
+		alpha:          n.alpha,
+		children:       data_structures.CreateSet[*Node](),
+		tags:           data_structures.CreateSet[string](),
+
+		autoOverlapEnabled: n.autoOverlapEnabled,
+	}
+	c.SetName(n.GetName())
+	for tag := range n.tags.Values() {
+		c.tags.Add(tag)
+	}
+
+	if n.animatedSprite != nil {
+		c.animatedSprite = n.animatedSprite.Clone()
+		c.texture = c.animatedSprite.GetTexture()
+	} else if n.texture != nil {
+		c.texture = n.texture.Clone()
+	}
+
+	if n.textInfo != nil {
+		info := *n.textInfo
+		c.textInfo = &info
+	}
+
+	// overlaps built by auto overlap are not copied
+	if n.overlap != nil && !n.autoOverlapChild {
+		switch o := n.overlap.(type) {
+		case *Overlap:
+			c.SetOverlap(o.Clone())
+		case *ComposedOverlap:
+			c.SetOverlap(o.Clone())
+		default:
+			fmt.Println(fmt.Errorf("cannot clone overlap of type %T (node_id=%d)", n.overlap, n.GetID()))
+		}
+	}
+
+	// children are cloned in order of creation, so copies keep the same order of IDs
+	children := n.GetChildren()
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].GetID() < children[j].GetID()
+	})
+	for _, child := range children {
+		c.AddChild(child.Clone())
+	}
+
+	return c
+}
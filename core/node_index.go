@@ -0,0 +1,235 @@
+package core
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/data_structures"
+	"sort"
+	"strings"
+)
+
+// nodeIndex keeps all nodes of a scene, including children, by id, name and tag,
+// so scene lookups do not traverse node hierarchy.
+// Nodes update index of their scene when they are renamed, tagged, or their children change.
+type nodeIndex struct {
+	byID   map[basic.IDType]*Node
+	byName map[string]data_structures.Set[*Node]
+	byTag  map[string]data_structures.Set[*Node]
+}
+
+func newNodeIndex() *nodeIndex {
+	return &nodeIndex{
+		byID:   make(map[basic.IDType]*Node),
+		byName: make(map[string]data_structures.Set[*Node]),
+		byTag:  make(map[string]data_structures.Set[*Node]),
+	}
+}
+
+// addTree adds node and its children to index.
+func (idx *nodeIndex) addTree(node *Node) {
+	if node.index != nil && node.index != idx {
+		node.index.removeTree(node)
+	}
+
+	node.index = idx
+	idx.byID[node.GetID()] = node
+	idx.addName(node, node.GetName())
+	for tag := range node.tags.Values() {
+		idx.addTag(node, tag)
+	}
+
+	for child := range node.children.Values() {
+		idx.addTree(child)
+	}
+}
+
+// removeTree removes node and its children from index.
+func (idx *nodeIndex) removeTree(node *Node) {
+	if node.index != idx {
+		return
+	}
+
+	node.index = nil
+	delete(idx.byID, node.GetID())
+	idx.removeName(node, node.GetName())
+	for tag := range node.tags.Values() {
+		idx.removeTag(node, tag)
+	}
+
+	for child := range node.children.Values() {
+		idx.removeTree(child)
+	}
+}
+
+func (idx *nodeIndex) addName(node *Node, name string) {
+	if name != "" {
+		addToGroup(idx.byName, name, node)
+	}
+}
+
+func (idx *nodeIndex) removeName(node *Node, name string) {
+	removeFromGroup(idx.byName, name, node)
+}
+
+func (idx *nodeIndex) addTag(node *Node, tag string) {
+	addToGroup(idx.byTag, tag, node)
+}
+
+func (idx *nodeIndex) removeTag(node *Node, tag string) {
+	removeFromGroup(idx.byTag, tag, node)
+}
+
+func addToGroup(groups map[string]data_structures.Set[*Node], key string, node *Node) {
+	group, ok := groups[key]
+	if !ok {
+		group = data_structures.CreateSet[*Node]()
+		groups[key] = group
+	}
+	group.Add(node)
+}
+
+func removeFromGroup(groups map[string]data_structures.Set[*Node], key string, node *Node) {
+	group, ok := groups[key]
+	if !ok {
+		return
+	}
+	group.Remove(node)
+	if group.Len() == 0 {
+		delete(groups, key)
+	}
+}
+
+// sortedGroup returns nodes of group in order of creation.
+func sortedGroup(group data_structures.Set[*Node]) []*Node {
+	result := make([]*Node, 0, group.Len())
+	for n := range group.Values() {
+		result = append(result, n)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetID() < result[j].GetID()
+	})
+	return result
+}
+
+// --- tags ---
+
+// AddTag adds tag to the node, tags are used to find groups of nodes with Scene.FindNodesByTag.
+func (n *Node) AddTag(tag string) {
+	if !n.tags.Add(tag) {
+		return
+	}
+	if n.index != nil {
+		n.index.addTag(n, tag)
+	}
+}
+
+// RemoveTag removes tag from the node, returns false if node has no such tag.
+func (n *Node) RemoveTag(tag string) bool {
+	if !n.tags.Remove(tag) {
+		return false
+	}
+	if n.index != nil {
+		n.index.removeTag(n, tag)
+	}
+	return true
+}
+
+// HasTag returns true if node has tag.
+func (n *Node) HasTag(tag string) bool {
+	return n.tags.Contains(tag)
+}
+
+// GetTags returns tags of the node sorted alphabetically.
+func (n *Node) GetTags() []string {
+	result := make([]string, 0, n.tags.Len())
+	for tag := range n.tags.Values() {
+		result = append(result, tag)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// SetName sets name of the node, names are used to find nodes with Scene.FindNodeByName and Scene.FindNodeByPath.
+func (n *Node) SetName(name string) {
+	if n.index != nil {
+		n.index.removeName(n, n.GetName())
+		n.index.addName(n, name)
+	}
+	n.Base.SetName(name)
+}
+
+// --- scene lookup ---
+
+// FindNode returns node of the scene or any of its descendants by id, nil if there is no such node.
+func (s *Scene) FindNode(id basic.IDType) *Node {
+	return s.index.byID[id]
+}
+
+// FindNodeByName returns node of the scene or any of its descendants with name, nil if there is no such node.
+// If several nodes have the same name, the earliest created one is returned.
+func (s *Scene) FindNodeByName(name string) *Node {
+	group, ok := s.index.byName[name]
+	if !ok {
+		return nil
+	}
+	return sortedGroup(group)[0]
+}
+
+// FindNodesByName returns all nodes of the scene and their descendants with name in order of creation.
+func (s *Scene) FindNodesByName(name string) []*Node {
+	group, ok := s.index.byName[name]
+	if !ok {
+		return []*Node{}
+	}
+	return sortedGroup(group)
+}
+
+// FindNodesByTag returns all nodes of the scene and their descendants with tag in order of creation.
+func (s *Scene) FindNodesByTag(tag string) []*Node {
+	group, ok := s.index.byTag[tag]
+	if !ok {
+		return []*Node{}
+	}
+	return sortedGroup(group)
+}
+
+// FindNodeByPath returns node by slash-separated names of nodes from node attached to scene to the node,
+// e.g. "ui/menu/start" is a node "start", child of "menu", which is a child of "ui" attached to scene.
+// Returns nil if there is no such node. If several nodes match, the earliest created one is returned.
+func (s *Scene) FindNodeByPath(path string) *Node {
+	names, err := splitPath(path)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	group, ok := s.index.byName[names[len(names)-1]]
+	if !ok {
+		return nil
+	}
+
+	for _, candidate := range sortedGroup(group) {
+		node := candidate
+		i := len(names) - 1
+		for ; i > 0; i-- {
+			node = node.GetParent()
+			if node == nil || node.GetName() != names[i-1] {
+				break
+			}
+		}
+		if i == 0 && node != nil && s.nodes.Contains(node) {
+			return candidate
+		}
+	}
+	return nil
+}
+
+func splitPath(path string) ([]string, error) {
+	names := strings.Split(path, "/")
+	for _, name := range names {
+		if name == "" {
+			return nil, fmt.Errorf("invalid node path %q", path)
+		}
+	}
+	return names, nil
+}
@@ -3,7 +3,6 @@ package core
 import (
 	"fmt"
 	"github.com/SemyonHoyrish/GoPlayEngine/basic"
-	"github.com/SemyonHoyrish/GoPlayEngine/input"
 	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
 )
 
@@ -36,6 +35,21 @@ func NewOverlap(leftTop basic.Point, rightBottom basic.Point) *Overlap {
 	}
 }
 
+// GetLeftTop returns left top corner of the overlap relative to the node position.
+func (over *Overlap) GetLeftTop() basic.Point {
+	return basic.Point{X: over.x1, Y: over.y1}
+}
+
+// GetRightBottom returns right bottom corner of the overlap relative to the node position.
+func (over *Overlap) GetRightBottom() basic.Point {
+	return basic.Point{X: over.x2, Y: over.y2}
+}
+
+// Clone returns copy of the overlap with the same coordinates, not attached to any node or composition.
+func (over *Overlap) Clone() *Overlap {
+	return NewOverlap(over.GetLeftTop(), over.GetRightBottom())
+}
+
 // SetNode internal function that links node and overlap. Should NOT be called by user.
 func (over *Overlap) SetNode(node *Node) bool {
 	if node == nil {
@@ -61,19 +75,67 @@ func (over *Overlap) SetComposedOverlap(compOver *ComposedOverlap) {
 	over.composedOverlap = compOver
 }
 
+// getWorldTransform returns world transform of the node this overlap is attached to, directly or through
+// ComposedOverlap. Returns false if overlap is not attached.
+func (over *Overlap) getWorldTransform() (basic.Transform, bool) {
+	if over.node != nil {
+		return over.node.GetWorldTransform(), true
+	} else if over.composedOverlap != nil && over.composedOverlap.node != nil {
+		return over.composedOverlap.node.GetWorldTransform(), true
+	}
+	return basic.Transform{}, false
+}
+
+// GetWorldCorners returns corners of overlap rectangle in world space (left top, right top, right bottom, left bottom),
+// taking into account rotation, scale and flip of the node. Returns false if overlap is not attached to a node.
+func (over *Overlap) GetWorldCorners() ([4]basic.Point, bool) {
+	t, ok := over.getWorldTransform()
+	if !ok {
+		return [4]basic.Point{}, false
+	}
+
+	return [4]basic.Point{
+		t.Apply(basic.Point{X: over.x1, Y: over.y1}),
+		t.Apply(basic.Point{X: over.x2, Y: over.y1}),
+		t.Apply(basic.Point{X: over.x2, Y: over.y2}),
+		t.Apply(basic.Point{X: over.x1, Y: over.y2}),
+	}, true
+}
+
 // GetAbsoluteValues is an internal function, which returns coordinates relative to world space, instead of node, Overlap attached to.
+// For rotated nodes returned values describe bounding box of the overlap.
 func (over *Overlap) GetAbsoluteValues() (float32, float32, float32, float32) {
-	var pos basic.Point
-	if over.node == nil && over.composedOverlap == nil {
+	corners, ok := over.GetWorldCorners()
+	if !ok {
 		// TODO: print error
 		return -1, -1, -1, -1
-	} else if over.node == nil && over.composedOverlap != nil {
-		pos = over.composedOverlap.GetAbsolutePosition()
-	} else {
-		pos = over.node.GetAbsolutePosition()
 	}
 
-	return over.x1 + pos.X, over.x2 + pos.X, over.y1 + pos.Y, over.y2 + pos.Y
+	x1, x2, y1, y2 := corners[0].X, corners[0].X, corners[0].Y, corners[0].Y
+	for _, c := range corners[1:] {
+		x1 = min(x1, c.X)
+		x2 = max(x2, c.X)
+		y1 = min(y1, c.Y)
+		y2 = max(y2, c.Y)
+	}
+
+	return x1, x2, y1, y2
+}
+
+// ContainsPoint returns true if point in world space is inside this Overlap.
+func (over *Overlap) ContainsPoint(p basic.Point) bool {
+	t, ok := over.getWorldTransform()
+	if !ok {
+		return false
+	}
+	inv, ok := t.Inverse()
+	if !ok {
+		return false
+	}
+
+	local := inv.Apply(p)
+	return local.X >= min(over.x1, over.x2) && local.X <= max(over.x1, over.x2) &&
+		local.Y >= min(over.y1, over.y2) && local.Y <= max(over.y1, over.y2)
 }
 
 // OverlapsWith returns true if this overlap has an intersection with `other`.
@@ -119,21 +181,42 @@ func (over *Overlap) OverlapsWith(other OverlapInterface) bool {
 		panic("Undefined overlap type")
 	}
 
-	x1, x2, y1, y2 := over.GetAbsoluteValues()
-	otherX1, otherX2, otherY1, otherY2 := otherOver.GetAbsoluteValues()
+	corners, ok := over.GetWorldCorners()
+	otherCorners, otherOk := otherOver.GetWorldCorners()
+	if !ok || !otherOk {
+		return false
+	}
 
-	cond := (x1 < otherX2) &&
-		(x2 > otherX1) &&
-		(y1 < otherY2) &&
-		(y2 > otherY1)
+	// Separating axis theorem, overlaps are convex quadrilaterals, so it is enough to check their edge normals.
+	for _, quad := range [][4]basic.Point{corners, otherCorners} {
+		for i := 0; i < 4; i++ {
+			edge := basic.Point{X: quad[(i+1)%4].X - quad[i].X, Y: quad[(i+1)%4].Y - quad[i].Y}
+			axis := basic.Point{X: -edge.Y, Y: edge.X}
 
-	return cond
+			min1, max1 := projectQuad(corners, axis)
+			min2, max2 := projectQuad(otherCorners, axis)
+			if max1 <= min2 || max2 <= min1 {
+				return false
+			}
+		}
+	}
+
+	return true
 }
 
 // MouseOver returns true of mouse is over this Overlap
-func (over *Overlap) MouseOver(m *input.Mouse) bool {
-	x1, x2, y1, y2 := over.GetAbsoluteValues()
-	mpos := m.GetPosition()
+func (over *Overlap) MouseOver(m PointerInterface) bool {
+	return over.ContainsPoint(m.GetPosition())
+}
 
-	return mpos.X >= x1 && mpos.X <= x2 && mpos.Y >= y1 && mpos.Y <= y2
+// projectQuad returns range of quad projection onto axis.
+func projectQuad(quad [4]basic.Point, axis basic.Point) (float32, float32) {
+	lo := quad[0].X*axis.X + quad[0].Y*axis.Y
+	hi := lo
+	for _, p := range quad[1:] {
+		v := p.X*axis.X + p.Y*axis.Y
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	return lo, hi
 }
@@ -2,13 +2,19 @@ package core
 
 import (
 	"github.com/SemyonHoyrish/GoPlayEngine/basic"
-	"github.com/SemyonHoyrish/GoPlayEngine/input"
 )
 
 type OverlapInterface interface {
 	basic.BaseInterface
 
 	OverlapsWith(OverlapInterface) bool
-	MouseOver(*input.Mouse) bool
+	MouseOver(PointerInterface) bool
+	ContainsPoint(basic.Point) bool
 	SetNode(*Node) bool
 }
+
+// PointerInterface is a pointing device with position in screen space, e.g. input.Mouse.
+// Core does not depend on input devices directly, so node trees can be built without cgo.
+type PointerInterface interface {
+	GetPosition() basic.Point
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/SemyonHoyrish/GoPlayEngine/basic"
 	"github.com/SemyonHoyrish/GoPlayEngine/data_structures"
 	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"github.com/SemyonHoyrish/GoPlayEngine/scheduler"
 )
 
 // Scene implements scene of game engine.
@@ -12,40 +13,60 @@ type Scene struct {
 	basic.Base
 
 	nodes data_structures.Set[*Node]
+	// index of nodes and their descendants
+	index *nodeIndex
 
 	bgColor primitive.Color
 
-	updateFunction func()
+	updateFunction      func()
+	fixedUpdateFunction func()
+
+	// lifecycle hooks
+	onEnter  func()
+	onExit   func()
+	onPause  func()
+	onResume func()
+
+	overlay bool
+
+	cameras []*Camera
+
+	scheduler *scheduler.Scheduler
+
+	worlds []World
 }
 
 func NewScene() *Scene {
 	return &Scene{
-		Base:    basic.MakeBase(),
-		nodes:   data_structures.CreateSet[*Node](),
-		bgColor: primitive.Color{0, 0, 0, 255},
+		Base:      basic.MakeBase(),
+		nodes:     data_structures.CreateSet[*Node](),
+		index:     newNodeIndex(),
+		bgColor:   primitive.Color{0, 0, 0, 255},
+		scheduler: scheduler.NewScheduler(),
 	}
 }
 
-// AddNode adds node to scene nodes
+// AddNode adds node to scene nodes, node and its descendants can be found with Find functions of the scene.
+// Node can be found only in the scene it was added to last.
 func (s *Scene) AddNode(node *Node) {
 	s.nodes.Add(node)
+	s.index.addTree(node)
 }
 
-// RemoveNode tries to find and remove node based on pointer equality, return true on success, false on fail
+// RemoveNode tries to find and remove node based on pointer equality, return true on success, false on fail.
+// Started components of the node and its children are destroyed.
 func (s *Scene) RemoveNode(node *Node) bool {
-	return s.nodes.Remove(node)
-}
-
-// FindNode tries to find node based on node id (any node embeds Base, so have GetID() function),
-// returns a pointer to the node on success, nil on fail
-func (s *Scene) FindNode(id basic.IDType) *Node {
-	for n := range s.nodes.Values() {
-		if n.GetID() == id {
-			return n
-		}
+	if !s.nodes.Remove(node) {
+		return false
 	}
+	s.index.removeTree(node)
+	node.destroyComponents()
+	return true
+}
 
-	return nil
+// HasNode returns true if node is attached to scene directly (not as a child of other node).
+func (s *Scene) HasNode(node *Node) bool {
+	return s.nodes.Contains(node)
 }
 
 // GetAllNodes returns all nodes attached to scene
@@ -78,3 +99,140 @@ func (s *Scene) SetUpdateFunction(updateFunction func()) {
 func (s *Scene) GetUpdateFunction() func() {
 	return s.updateFunction
 }
+
+// SetFixedUpdateFunction sets function that called with fixed rate of simulated time (see EngineConfig.FixedTickRate),
+// zero or more times before every frame of this scene. Use Engine.GetFixedDeltaTime to get its time step.
+func (s *Scene) SetFixedUpdateFunction(fixedUpdateFunction func()) {
+	s.fixedUpdateFunction = fixedUpdateFunction
+}
+
+// GetFixedUpdateFunction returns function that called with fixed rate of simulated time.
+// Used internally
+func (s *Scene) GetFixedUpdateFunction() func() {
+	return s.fixedUpdateFunction
+}
+
+// SetOnEnter sets function called when scene becomes active, by being pushed to or replacing other scene in scene manager.
+func (s *Scene) SetOnEnter(onEnter func()) {
+	s.onEnter = onEnter
+}
+
+// SetOnExit sets function called when scene stops being active, by being popped or replaced in scene manager.
+func (s *Scene) SetOnExit(onExit func()) {
+	s.onExit = onExit
+}
+
+// SetOnPause sets function called when other scene is pushed on top of this scene in scene manager.
+func (s *Scene) SetOnPause(onPause func()) {
+	s.onPause = onPause
+}
+
+// SetOnResume sets function called when this scene becomes active again, after scene on top of it was popped.
+func (s *Scene) SetOnResume(onResume func()) {
+	s.onResume = onResume
+}
+
+// Enter is an internal function, which calls OnEnter hook.
+func (s *Scene) Enter() {
+	if s.onEnter != nil {
+		s.onEnter()
+	}
+}
+
+// Exit is an internal function, which calls OnExit hook.
+func (s *Scene) Exit() {
+	if s.onExit != nil {
+		s.onExit()
+	}
+}
+
+// Pause is an internal function, which calls OnPause hook.
+func (s *Scene) Pause() {
+	if s.onPause != nil {
+		s.onPause()
+	}
+}
+
+// Resume is an internal function, which calls OnResume hook.
+func (s *Scene) Resume() {
+	if s.onResume != nil {
+		s.onResume()
+	}
+}
+
+// SetOverlay marks scene as overlay, scenes below overlay scene in scene manager are rendered (but not updated)
+// before it, e.g. gameplay under pause menu. Background of overlay scene is blended over scene below,
+// so it is possible to dim it with semi-transparent color.
+func (s *Scene) SetOverlay(overlay bool) {
+	s.overlay = overlay
+}
+
+// IsOverlay returns true if scene is marked as overlay.
+func (s *Scene) IsOverlay() bool {
+	return s.overlay
+}
+
+// AddCamera attaches camera to scene, scene is rendered once by every camera into its viewport,
+// in order cameras were added. Scene without cameras is rendered in window pixel space.
+func (s *Scene) AddCamera(camera *Camera) {
+	s.cameras = append(s.cameras, camera)
+}
+
+// RemoveCamera detaches camera from scene, returns true on success, false if camera was not attached.
+func (s *Scene) RemoveCamera(camera *Camera) bool {
+	for i, c := range s.cameras {
+		if c == camera {
+			s.cameras = append(s.cameras[:i], s.cameras[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// SetCamera replaces all cameras of the scene with the single camera, nil removes all cameras.
+func (s *Scene) SetCamera(camera *Camera) {
+	s.cameras = nil
+	if camera != nil {
+		s.cameras = append(s.cameras, camera)
+	}
+}
+
+// GetCamera returns first camera attached to scene, nil if there are no cameras.
+func (s *Scene) GetCamera() *Camera {
+	if len(s.cameras) == 0 {
+		return nil
+	}
+	return s.cameras[0]
+}
+
+// GetCameras returns all cameras attached to scene.
+func (s *Scene) GetCameras() []*Camera {
+	return s.cameras
+}
+
+// GetScheduler returns scheduler of timers and coroutines of the scene, which is updated every frame
+// after update function while scene is active, so they are paused together with the scene.
+func (s *Scene) GetScheduler() *scheduler.Scheduler {
+	return s.scheduler
+}
+
+// AddWorld attaches world to the scene, worlds are updated and rendered in order they were added.
+func (s *Scene) AddWorld(world World) {
+	s.worlds = append(s.worlds, world)
+}
+
+// RemoveWorld detaches world from the scene, returns false if world is not attached.
+func (s *Scene) RemoveWorld(world World) bool {
+	for i, w := range s.worlds {
+		if w == world {
+			s.worlds = append(s.worlds[:i], s.worlds[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// GetWorlds returns worlds attached to the scene.
+func (s *Scene) GetWorlds() []World {
+	return s.worlds
+}
@@ -10,6 +10,8 @@ import (
 type Texture struct {
 	primitive primitive.PrimitiveInterface
 	image     *resource.Image
+	// part of the image used by texture, whole image if nil
+	region *basic.Rect
 }
 
 // NewTextureFromImage creates texture source based on Image resource
@@ -17,6 +19,11 @@ func NewTextureFromImage(i *resource.Image) *Texture {
 	return &Texture{image: i}
 }
 
+// NewTextureFromRegion creates texture source based on part of Image resource, e.g. region of resource.SpriteSheet
+func NewTextureFromRegion(i *resource.Image, region basic.Rect) *Texture {
+	return &Texture{image: i, region: &region}
+}
+
 // NewTextureFromPrimitive creates texture source based on primitive
 func NewTextureFromPrimitive(p primitive.PrimitiveInterface) *Texture {
 	return &Texture{primitive: p}
@@ -36,8 +43,8 @@ func (t *Texture) GetSize() basic.Size {
 			}
 		case primitive.CirclePrimitive:
 			return basic.Size{
-				Width:  t.primitive.(primitive.Circle).Radius,
-				Height: t.primitive.(primitive.Circle).Radius,
+				Width:  t.primitive.(primitive.Circle).Radius * 2,
+				Height: t.primitive.(primitive.Circle).Radius * 2,
 			}
 		case primitive.EllipsePrimitive:
 		case primitive.LinePrimitive:
@@ -56,18 +63,47 @@ func (t *Texture) GetSize() basic.Size {
 
 		panic("to implement")
 	} else {
-		if surf := t.image.GetSurface(); surf != nil {
-			return basic.Size{Height: float32(surf.H), Width: float32(surf.W)}
-		} else {
+		if t.image == nil {
 			return basic.Size{}
 		}
+		if t.region != nil {
+			return basic.Size{Width: t.region.Width, Height: t.region.Height}
+		}
+		return t.image.GetSize()
 	}
 }
 
 // GetPrimitive returns primitive on which this texture was created, nil if was created on Image.
 func (t *Texture) GetPrimitive() primitive.PrimitiveInterface { return t.primitive }
 
+// SetPrimitive replaces primitive of the texture, e.g. to change its color. Image of the texture is removed.
+func (t *Texture) SetPrimitive(p primitive.PrimitiveInterface) {
+	t.primitive = p
+	t.image = nil
+	t.region = nil
+}
+
 // GetImage returns Image in which this texture was created, nil if was created on primitive.
 func (t *Texture) GetImage() *resource.Image { return t.image }
 
+// GetRegion returns part of the image used by texture, nil if whole image is used.
+func (t *Texture) GetRegion() *basic.Rect { return t.region }
+
+// SetRegion sets part of the image used by texture, nil to use whole image.
+func (t *Texture) SetRegion(region *basic.Rect) {
+	if region == nil {
+		t.region = nil
+		return
+	}
+	r := *region
+	t.region = &r
+}
+
+// Clone returns copy of the texture, sharing its image.
+func (t *Texture) Clone() *Texture {
+	c := &Texture{primitive: t.primitive, image: t.image}
+	c.SetRegion(t.region)
+	return c
+}
+
 // TODO: ?Move creating Texture to core.Texture instead of Engine.render
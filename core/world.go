@@ -0,0 +1,28 @@
+package core
+
+import "github.com/SemyonHoyrish/GoPlayEngine/basic"
+
+// World is a container of objects living in scene without nodes, e.g. ecs.World, attached to scene with Scene.AddWorld.
+// Worlds of the active scene are updated by engine every frame after node components,
+// and their sprites are rendered together with scene nodes.
+type World interface {
+	// Update advances world by dt seconds.
+	Update(dt float64)
+	// AppendSprites appends sprites to be rendered in current frame to sprites and returns result.
+	AppendSprites(sprites []Sprite) []Sprite
+}
+
+// Sprite is a texture rendered by engine the same way as texture of object node.
+type Sprite struct {
+	// ID of the object sprite belongs to, used in error messages.
+	ID      basic.IDType
+	Texture *Texture
+	// Bounds of the texture in sprite space, see Node.GetLocalBounds.
+	Bounds basic.Rect
+	// Transform from sprite space to world space.
+	Transform basic.Transform
+	// Layer is compared with layers of nodes of the scene, sprites are rendered after nodes of the same layer.
+	Layer LayerType
+	// Alpha is opacity from 0 (invisible) to 1 (opaque).
+	Alpha float32
+}
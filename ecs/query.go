@@ -0,0 +1,64 @@
+package ecs
+
+// Queries call function for every entity having all requested components, iterating storage of the first component,
+// so it is faster to request the rarest component first. During query entities and components can be safely
+// destroyed, added and removed, such structural changes are applied after the query.
+
+// Each calls function for every entity having component A.
+func Each[A any](w *World, function func(e Entity, a *A)) {
+	sa := getStorage[A](w, false)
+	if sa == nil {
+		return
+	}
+
+	w.beginIteration()
+	defer w.endIteration()
+
+	for i := 0; i < len(sa.values); i++ {
+		function(sa.entities[i], &sa.values[i])
+	}
+}
+
+// Each2 calls function for every entity having components A and B.
+func Each2[A any, B any](w *World, function func(e Entity, a *A, b *B)) {
+	sa, sb := getStorage[A](w, false), getStorage[B](w, false)
+	if sa == nil || sb == nil {
+		return
+	}
+
+	w.beginIteration()
+	defer w.endIteration()
+
+	for i := 0; i < len(sa.values); i++ {
+		e := sa.entities[i]
+		b := sb.get(e)
+		if b == nil {
+			continue
+		}
+		function(e, &sa.values[i], b)
+	}
+}
+
+// Each3 calls function for every entity having components A, B and C.
+func Each3[A any, B any, C any](w *World, function func(e Entity, a *A, b *B, c *C)) {
+	sa, sb, sc := getStorage[A](w, false), getStorage[B](w, false), getStorage[C](w, false)
+	if sa == nil || sb == nil || sc == nil {
+		return
+	}
+
+	w.beginIteration()
+	defer w.endIteration()
+
+	for i := 0; i < len(sa.values); i++ {
+		e := sa.entities[i]
+		b := sb.get(e)
+		if b == nil {
+			continue
+		}
+		c := sc.get(e)
+		if c == nil {
+			continue
+		}
+		function(e, &sa.values[i], b, c)
+	}
+}
@@ -0,0 +1,74 @@
+package ecs
+
+import (
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/core"
+)
+
+// Transform is a component with position, rotation and scale of entity in world space.
+// Transform have to be initialized with NewTransform, because zero scale makes entity invisible.
+type Transform struct {
+	Position basic.Point
+	// Rotation in degrees clockwise.
+	Rotation float32
+	Scale    basic.Point
+}
+
+// NewTransform creates transform at position without rotation and with scale {1, 1}.
+func NewTransform(position basic.Point) Transform {
+	return Transform{Position: position, Scale: basic.Point{X: 1, Y: 1}}
+}
+
+// GetMatrix returns transform from entity space to world space.
+func (t Transform) GetMatrix() basic.Transform {
+	return basic.TranslationTransform(t.Position.X, t.Position.Y).
+		Multiply(basic.RotationTransform(t.Rotation)).
+		Multiply(basic.ScaleTransform(t.Scale.X, t.Scale.Y))
+}
+
+// Sprite is a component rendering texture at Transform of entity, in the same way as texture of object node.
+// Sprite have to be initialized with NewSprite.
+type Sprite struct {
+	Texture *core.Texture
+	// Size overrides size of the texture, size of the texture is used if zero.
+	Size basic.Size
+	// Pivot is a point of the sprite placed at position of entity, relative to its size (see core.Node.SetPivot).
+	Pivot basic.Point
+	Layer core.LayerType
+	// Alpha is opacity from 0 (invisible) to 1 (opaque).
+	Alpha float32
+}
+
+// NewSprite creates opaque sprite with pivot in its center.
+func NewSprite(texture *core.Texture) Sprite {
+	return Sprite{Texture: texture, Pivot: basic.Point{X: 0.5, Y: 0.5}, Alpha: 1}
+}
+
+// AppendSprites appends sprites of entities having Transform and Sprite components, implements core.World.
+func (w *World) AppendSprites(sprites []core.Sprite) []core.Sprite {
+	Each2(w, func(e Entity, s *Sprite, t *Transform) {
+		if s.Texture == nil || s.Alpha <= 0 {
+			return
+		}
+
+		size := s.Size
+		if size.Width == 0 && size.Height == 0 {
+			size = s.Texture.GetSize()
+		}
+
+		sprites = append(sprites, core.Sprite{
+			ID:      e,
+			Texture: s.Texture,
+			Bounds: basic.Rect{
+				X:      -s.Pivot.X * size.Width,
+				Y:      -s.Pivot.Y * size.Height,
+				Width:  size.Width,
+				Height: size.Height,
+			},
+			Transform: t.GetMatrix(),
+			Layer:     s.Layer,
+			Alpha:     s.Alpha,
+		})
+	})
+	return sprites
+}
@@ -0,0 +1,134 @@
+package ecs
+
+import "reflect"
+
+type storageInterface interface {
+	remove(e Entity)
+	has(e Entity) bool
+	len() int
+}
+
+// storage keeps components of one type in dense slices, so queries iterate memory sequentially.
+type storage[T any] struct {
+	index    map[Entity]int
+	entities []Entity
+	values   []T
+}
+
+func newStorage[T any]() *storage[T] {
+	return &storage[T]{index: make(map[Entity]int)}
+}
+
+func (s *storage[T]) set(e Entity, value T) {
+	if i, ok := s.index[e]; ok {
+		s.values[i] = value
+		return
+	}
+
+	s.index[e] = len(s.values)
+	s.entities = append(s.entities, e)
+	s.values = append(s.values, value)
+}
+
+func (s *storage[T]) get(e Entity) *T {
+	i, ok := s.index[e]
+	if !ok {
+		return nil
+	}
+	return &s.values[i]
+}
+
+// remove swaps component of entity with the last one, so order of components changes.
+func (s *storage[T]) remove(e Entity) {
+	i, ok := s.index[e]
+	if !ok {
+		return
+	}
+
+	last := len(s.values) - 1
+	s.values[i] = s.values[last]
+	s.entities[i] = s.entities[last]
+	s.index[s.entities[i]] = i
+
+	var zero T
+	s.values[last] = zero
+	s.values = s.values[:last]
+	s.entities = s.entities[:last]
+	delete(s.index, e)
+}
+
+func (s *storage[T]) has(e Entity) bool {
+	_, ok := s.index[e]
+	return ok
+}
+
+func (s *storage[T]) len() int {
+	return len(s.values)
+}
+
+// getStorage returns storage of components of type T, creating it if create is true.
+func getStorage[T any](w *World, create bool) *storage[T] {
+	t := reflect.TypeFor[T]()
+	if s, ok := w.storages[t]; ok {
+		return s.(*storage[T])
+	}
+	if !create {
+		return nil
+	}
+
+	s := newStorage[T]()
+	w.storages[t] = s
+	return s
+}
+
+// Add sets component of type T of entity, replacing existing one. Does nothing if entity does not exist.
+// When called during query for new component type of entity, component is added after query.
+func Add[T any](w *World, e Entity, component T) {
+	if !w.IsAlive(e) {
+		return
+	}
+
+	s := getStorage[T](w, true)
+	if w.iterating > 0 && !s.has(e) {
+		w.pending = append(w.pending, func() { Add(w, e, component) })
+		return
+	}
+	s.set(e, component)
+}
+
+// Get returns pointer to component of type T of entity, nil if entity has no such component.
+// Pointer is valid until components of type T are added or removed.
+func Get[T any](w *World, e Entity) *T {
+	s := getStorage[T](w, false)
+	if s == nil {
+		return nil
+	}
+	return s.get(e)
+}
+
+// Has returns true if entity has component of type T.
+func Has[T any](w *World, e Entity) bool {
+	s := getStorage[T](w, false)
+	return s != nil && s.has(e)
+}
+
+// Remove removes component of type T from entity. When called during query, component is removed after query.
+func Remove[T any](w *World, e Entity) {
+	if w.iterating > 0 {
+		w.pending = append(w.pending, func() { Remove[T](w, e) })
+		return
+	}
+
+	if s := getStorage[T](w, false); s != nil {
+		s.remove(e)
+	}
+}
+
+// Count returns number of entities having component of type T.
+func Count[T any](w *World) int {
+	s := getStorage[T](w, false)
+	if s == nil {
+		return 0
+	}
+	return s.len()
+}
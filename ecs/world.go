@@ -0,0 +1,147 @@
+// Package ecs implements entity-component-system, an alternative to the node tree for large numbers of simple objects.
+//
+// Entities are ids, components are plain structs kept in dense typed storages, and systems are functions
+// updating entities selected by queries. World implements core.World, so it can be attached to a scene
+// with core.Scene.AddWorld: its systems are updated every frame, and entities with Transform and Sprite
+// components are rendered together with scene nodes.
+package ecs
+
+import (
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"reflect"
+	"sort"
+)
+
+// Entity is an id of an object in World, ids are unique across entities and nodes.
+type Entity = basic.IDType
+
+// World keeps entities, their components and systems.
+// World have to be initialized with NewWorld.
+type World struct {
+	entities map[Entity]struct{}
+	storages map[reflect.Type]storageInterface
+
+	systems       []systemEntry
+	systemsSorted bool
+
+	// structural changes made during query are applied after it
+	iterating int
+	pending   []func()
+}
+
+func NewWorld() *World {
+	return &World{
+		entities: make(map[Entity]struct{}),
+		storages: make(map[reflect.Type]storageInterface),
+	}
+}
+
+// NewEntity creates entity without components.
+func (w *World) NewEntity() Entity {
+	e := basic.NextID()
+	w.entities[e] = struct{}{}
+	return e
+}
+
+// Destroy removes entity with all its components. When called during query, entity is removed after query.
+func (w *World) Destroy(e Entity) {
+	if w.iterating > 0 {
+		w.pending = append(w.pending, func() { w.Destroy(e) })
+		return
+	}
+
+	if _, ok := w.entities[e]; !ok {
+		return
+	}
+	for _, s := range w.storages {
+		s.remove(e)
+	}
+	delete(w.entities, e)
+}
+
+// IsAlive returns true if entity exists and was not destroyed.
+func (w *World) IsAlive(e Entity) bool {
+	_, ok := w.entities[e]
+	return ok
+}
+
+// Len returns number of entities.
+func (w *World) Len() int {
+	return len(w.entities)
+}
+
+// beginIteration prevents storages from changing structure while query iterates them.
+func (w *World) beginIteration() {
+	w.iterating++
+}
+
+// endIteration applies structural changes made during queries, when the outermost query ends.
+func (w *World) endIteration() {
+	w.iterating--
+	if w.iterating > 0 {
+		return
+	}
+
+	for len(w.pending) > 0 {
+		pending := w.pending
+		w.pending = nil
+		for _, change := range pending {
+			change()
+		}
+	}
+}
+
+// System updates entities of world every frame.
+type System interface {
+	Update(w *World, dt float64)
+}
+
+// SystemFunc allows to use function as System.
+type SystemFunc func(w *World, dt float64)
+
+func (f SystemFunc) Update(w *World, dt float64) {
+	f(w, dt)
+}
+
+type systemEntry struct {
+	system System
+	order  int
+}
+
+// AddSystem adds system, systems are updated in ascending order, systems with the same order in order they were added.
+func (w *World) AddSystem(system System, order int) {
+	w.systems = append(w.systems, systemEntry{system: system, order: order})
+	w.systemsSorted = false
+}
+
+// RemoveSystem removes system, returns false if system was not added.
+// Systems of types, which cannot be compared (e.g. SystemFunc), cannot be removed.
+func (w *World) RemoveSystem(system System) bool {
+	if !reflect.TypeOf(system).Comparable() {
+		return false
+	}
+
+	for i, entry := range w.systems {
+		if entry.system == system {
+			w.systems = append(w.systems[:i], w.systems[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Update runs all systems, implements core.World.
+func (w *World) Update(dt float64) {
+	if !w.systemsSorted {
+		sort.SliceStable(w.systems, func(i, j int) bool {
+			return w.systems[i].order < w.systems[j].order
+		})
+		w.systemsSorted = true
+	}
+
+	// systems may be added or removed by other systems
+	systems := append([]systemEntry(nil), w.systems...)
+	for _, entry := range systems {
+		entry.system.Update(w, dt)
+	}
+}
@@ -2,10 +2,12 @@ package goplayengine
 
 import (
 	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
 	"github.com/SemyonHoyrish/GoPlayEngine/core"
 	"github.com/SemyonHoyrish/GoPlayEngine/input"
 	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
-	"github.com/veandco/go-sdl2/gfx"
+	"github.com/SemyonHoyrish/GoPlayEngine/render"
+	"github.com/SemyonHoyrish/GoPlayEngine/tween"
 	"github.com/veandco/go-sdl2/sdl"
 	"github.com/veandco/go-sdl2/ttf"
 	"os"
@@ -14,66 +16,119 @@ import (
 
 // Engine is main structure, which links everything together, and behaves like entry point for your game.
 type Engine struct {
+	sceneManager                  *SceneManager
 	activeScene                   *core.Scene
 	activeSceneNoFunctionReported bool
 	running                       bool
 	started                       bool
+	exitRequested                 bool
 	exitCode                      int
 
 	window   *sdl.Window
-	renderer *sdl.Renderer
+	renderer render.Renderer
 
-	mouse    *input.Mouse
-	keyboard *input.Keyboard
+	// headless mode fields
+	headless     bool
+	surface      *sdl.Surface
+	virtualTicks uint64
+
+	closed    bool
+	exitHooks []func()
+
+	mouse     *input.Mouse
+	keyboard  *input.Keyboard
+	gamepads  *input.Gamepads
+	actions   *input.ActionMap
+	textInput *input.TextInput
+	// inputs are given to node components
+	inputs *core.InputDevices
 
 	previousTicks uint64
 	deltaTime     uint64
 
-	// TODO: move to engine configuration
-	maxEventsPolledPerRender int
+	previousTime       float64
+	deltaSeconds       float64
+	fixedAccumulator   float64
+	interpolationAlpha float64
+
+	nextFrameTime float64
+	frameStats    *frameStatsRecorder
+
+	tweens *tween.Manager
+
+	config EngineConfig
 
 	cleanUp func()
 }
 
+// NewEngine creates Engine with DefaultEngineConfig, panics if engine cannot be initialized.
 func NewEngine() *Engine {
-	engine := &Engine{
-		activeScene:                   nil,
-		activeSceneNoFunctionReported: false,
-		running:                       false,
-		started:                       false,
-		exitCode:                      0,
-		window:                        nil,
-		renderer:                      nil,
-		mouse:                         input.NewMouse(),
-		keyboard:                      input.NewKeyboard(),
-		previousTicks:                 0,
-		deltaTime:                     0,
+	engine, err := NewEngineWithConfig(DefaultEngineConfig())
+	if err != nil {
+		panic(err)
+	}
 
-		maxEventsPolledPerRender: 10,
+	return engine
+}
+
+// NewEngineWithConfig creates Engine with provided configuration,
+// returns error if config is invalid or SDL cannot be initialized.
+func NewEngineWithConfig(config EngineConfig) (*Engine, error) {
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid engine config: %w", err)
 	}
 
-	if err := sdl.Init(sdl.INIT_EVERYTHING); err != nil {
-		panic(err)
+	if config.Headless {
+		return newHeadlessEngine(config)
+	}
+	if config.Backend != RendererBackendSDL {
+		return nil, fmt.Errorf("invalid engine config: only SDL renderer backend can be used with window")
+	}
+
+	if err := sdl.Init(config.InitFlags); err != nil {
+		return nil, fmt.Errorf("cannot initialize sdl: %w", err)
 	}
 
 	if err := ttf.Init(); err != nil {
-		panic(err)
+		sdl.Quit()
+		return nil, fmt.Errorf("cannot initialize ttf: %w", err)
 	}
 
-	w, err := sdl.CreateWindow("GAME", sdl.WINDOWPOS_UNDEFINED, sdl.WINDOWPOS_UNDEFINED, 720, 480, sdl.WINDOW_SHOWN)
+	w, err := sdl.CreateWindow(config.Title, sdl.WINDOWPOS_UNDEFINED, sdl.WINDOWPOS_UNDEFINED, config.Width, config.Height, config.windowFlags())
 	if err != nil {
-		panic(err)
+		ttf.Quit()
+		sdl.Quit()
+		return nil, fmt.Errorf("cannot create window: %w", err)
 	}
 
-	r, err := sdl.CreateRenderer(w, -1, sdl.RENDERER_ACCELERATED|sdl.RENDERER_PRESENTVSYNC)
+	index, flags, err := config.rendererIndexAndFlags()
 	if err != nil {
-		panic(err)
+		w.Destroy()
+		ttf.Quit()
+		sdl.Quit()
+		return nil, fmt.Errorf("cannot create renderer: %w", err)
 	}
 
-	engine.window = w
-	engine.renderer = r
+	r, err := sdl.CreateRenderer(w, index, flags)
+	if err != nil {
+		w.Destroy()
+		ttf.Quit()
+		sdl.Quit()
+		return nil, fmt.Errorf("cannot create renderer: %w", err)
+	}
+
+	if config.Icon != nil {
+		if surf := config.Icon.GetSurface(); surf != nil {
+			w.SetIcon(surf)
+		} else {
+			fmt.Println(fmt.Errorf("cannot set window icon, image is not loaded"))
+		}
+	}
 
+	engine := newEngine(config, render.NewSDLRenderer(r))
+	engine.window = w
 	engine.previousTicks = engine.GetTicks()
+	engine.previousTime = engine.getTime()
 
 	engine.cleanUp = func() {
 		engine.renderer.Destroy()
@@ -82,14 +137,59 @@ func NewEngine() *Engine {
 		sdl.Quit()
 	}
 
+	return engine, nil
+}
+
+// newEngine creates Engine with state shared by window and headless modes,
+// window, offscreen surface and clean up function are set by caller.
+func newEngine(config EngineConfig, renderer render.Renderer) *Engine {
+	engine := &Engine{
+		activeScene:                   nil,
+		activeSceneNoFunctionReported: false,
+		running:                       false,
+		started:                       false,
+		exitRequested:                 false,
+		exitCode:                      0,
+		renderer:                      renderer,
+		mouse:                         input.NewMouse(),
+		keyboard:                      input.NewKeyboard(),
+		gamepads:                      input.NewGamepads(),
+		textInput:                     input.NewTextInput(),
+		previousTicks:                 0,
+		deltaTime:                     0,
+
+		config:     config,
+		frameStats: newFrameStatsRecorder(),
+		tweens:     tween.NewManager(),
+	}
+
+	engine.sceneManager = newSceneManager(engine.onActiveSceneChanged)
+	engine.actions = input.NewActionMap(engine.keyboard, engine.mouse, engine.gamepads)
+	engine.inputs = &core.InputDevices{
+		Mouse:     engine.mouse,
+		Keyboard:  engine.keyboard,
+		Gamepads:  engine.gamepads,
+		Actions:   engine.actions,
+		TextInput: engine.textInput,
+	}
+
 	return engine
 }
 
+// GetConfig returns configuration Engine was created with.
+func (e *Engine) GetConfig() EngineConfig {
+	return e.config
+}
+
 // SetActiveScene sets active scene in the engine, which will be used
-// to render text frame
+// to render text frame.
+// Same as GetSceneManager().Replace(scene, nil), nil scene removes top scene of the stack (GetSceneManager().Pop(nil)).
 func (e *Engine) SetActiveScene(scene *core.Scene) {
-	e.activeScene = scene
-	e.activeSceneNoFunctionReported = false
+	if scene == nil {
+		e.sceneManager.Pop(nil)
+		return
+	}
+	e.sceneManager.Replace(scene, nil)
 }
 
 // GetActiveScene returns current scene of the Engine
@@ -97,110 +197,87 @@ func (e *Engine) GetActiveScene() *core.Scene {
 	return e.activeScene
 }
 
-func (e *Engine) render(nodes []*core.Node) {
+// GetSceneManager returns Engine instance of SceneManager, which controls active scene.
+func (e *Engine) GetSceneManager() *SceneManager {
+	return e.sceneManager
+}
+
+// GetTweens returns manager of tweens, which is updated every frame before rendering.
+func (e *Engine) GetTweens() *tween.Manager {
+	return e.tweens
+}
+
+// onActiveSceneChanged is called by SceneManager when scene on top of its stack changes.
+func (e *Engine) onActiveSceneChanged(scene *core.Scene) {
+	e.activeScene = scene
+	e.activeSceneNoFunctionReported = false
+}
+
+// renderScene renders background and nodes of the scene moved by offset.
+func (e *Engine) renderScene(scene *core.Scene, offset basic.Point) {
+	size := e.renderer.GetOutputSize()
+	e.renderer.FillRect(basic.Rect{X: offset.X, Y: offset.Y, Width: size.Width, Height: size.Height}, scene.GetBackgroundColor())
+	core.DrawScene(e.renderer, scene, offset)
+}
+
+// updateComponents updates components of nodes and their children in hierarchy order, parent is nil for nodes of scene.
+// Nodes removed by components earlier in the same frame are skipped.
+func (e *Engine) updateComponents(scene *core.Scene, nodes []*core.Node, parent *core.Node) {
 	sort.Slice(nodes, func(i, j int) bool {
-		return nodes[i].GetLayer() < nodes[j].GetLayer()
+		return nodes[i].GetID() < nodes[j].GetID()
 	})
 
 	for _, node := range nodes {
-		if node.AutoOverlapEnabled() {
-			node.BuildAutoOverlap(false, nil, nil)
+		if parent == nil && !scene.HasNode(node) || parent != nil && node.GetParent() != parent {
+			continue
 		}
 
-		switch node.GetType() {
-		case core.NodeTypeObject:
-			t := node.GetTexture()
-
-			if t != nil {
-				size := node.GetCalculatedSize()
-
-				if t.GetPrimitive() != nil {
-					// TODO: handle errors
-					prim := t.GetPrimitive()
-
-					c := prim.GetColor()
-					e.renderer.SetDrawColor(c.R, c.G, c.B, c.A)
-
-					switch prim.GetPrimitiveType() {
-					case primitive.RectanglePrimitive:
-						err := e.renderer.FillRectF(&sdl.FRect{
-							X: node.GetAbsolutePosition().X - size.Width/2,
-							Y: node.GetAbsolutePosition().Y - size.Height/2,
-							W: size.Width,
-							H: size.Height,
-						})
-						if err != nil {
-							panic(err)
-						}
-					case primitive.CirclePrimitive:
-						if size.Width != size.Height {
-							fmt.Println(fmt.Errorf("circle width and height differ, possibly trying to override with node size (node id = %d)", node.GetID()))
-							break
-						}
-						gfx.FilledCircleColor(
-							e.renderer,
-							int32(node.GetAbsolutePosition().X-size.Width/2),
-							int32(node.GetAbsolutePosition().Y-size.Height/2),
-							int32(size.Width),
-							c,
-						)
-					case primitive.EllipsePrimitive:
-						panic("TODO implement")
-					case primitive.LinePrimitive:
-						pos := node.GetAbsolutePosition()
-						e.renderer.DrawLineF(pos.X-size.Width/2, pos.Y-size.Height/2, pos.X+size.Width/2, pos.Y+size.Height/2)
-					}
-				} else if t.GetImage() != nil {
-					image := t.GetImage()
-					if image.GetSurface() == nil {
-						fmt.Println(fmt.Errorf("no image for node id %d", node.GetID()))
-					} else {
-						tx, err := e.renderer.CreateTextureFromSurface(image.GetSurface())
-						_ = err // TODO:
-
-						e.renderer.CopyF(tx, nil, &sdl.FRect{
-							X: node.GetAbsolutePosition().X - size.Width/2,
-							Y: node.GetAbsolutePosition().Y - size.Height/2,
-							W: size.Width,
-							H: size.Height,
-						})
-
-						tx.Destroy()
-					}
-				} else {
-					fmt.Println(fmt.Errorf("node has empty texture (node id = %d)", node.GetID()))
-				}
-			}
-
-		case core.NodeTypeText:
-			textInfo := node.GetTextInfo()
-			font := textInfo.Font
-			ttfFont := font.GetTTFFont(textInfo.TextSize)
-			// TODO: handle error
-			//surf, _ := ttfFont.RenderUTF8Solid(textContent, textNode.GetColor())
-			surf, _ := ttfFont.RenderUTF8Blended(textInfo.Text, textInfo.Color)
-
-			size := node.GetCalculatedSize()
+		node.UpdateComponents(scene, e.inputs, e.deltaSeconds)
+		e.updateComponents(scene, node.GetChildren(), node)
+	}
+}
 
-			tx, _ := e.renderer.CreateTextureFromSurface(surf)
-			e.renderer.CopyF(tx, nil, &sdl.FRect{
-				X: node.GetAbsolutePosition().X - size.Width/2,
-				Y: node.GetAbsolutePosition().Y - size.Height/2,
-				W: size.Width,
-				H: size.Height,
-			})
+// updateWorlds updates worlds attached to the active scene.
+func (e *Engine) updateWorlds() {
+	if e.activeScene == nil {
+		return
+	}
 
-			tx.Destroy()
+	// worlds may be added or removed by systems
+	worlds := append([]core.World(nil), e.activeScene.GetWorlds()...)
+	for _, world := range worlds {
+		world.Update(e.deltaSeconds)
+	}
+}
 
-		case core.NodeTypeBase:
-			// We do not need to do anything when we encounter BaseNode, at least at the moment
+// updateAnimations advances animated sprites of nodes and their children.
+func (e *Engine) updateAnimations(nodes []*core.Node) {
+	for _, node := range nodes {
+		if sprite := node.GetAnimatedSprite(); sprite != nil {
+			sprite.Update(e.deltaSeconds)
 		}
+		e.updateAnimations(node.GetChildren())
+	}
+}
 
-		childNodes := node.GetChildren()
-		e.render(childNodes)
+// updateCameras moves cameras of the active scene to their targets, so they are up-to-date before rendering.
+func (e *Engine) updateCameras() {
+	if e.activeScene == nil {
+		return
+	}
+
+	size := e.renderer.GetOutputSize()
+	for _, camera := range e.activeScene.GetCameras() {
+		camera.SetScreenSize(size)
+		camera.Update(e.deltaSeconds)
 	}
 }
 
+// GetRenderer returns backend used to render frames.
+func (e *Engine) GetRenderer() render.Renderer {
+	return e.renderer
+}
+
 // GetMouse returns Engine instance of input.Mouse, the only initialized instance you should use
 func (e *Engine) GetMouse() *input.Mouse {
 	return e.mouse
@@ -211,19 +288,40 @@ func (e *Engine) GetKeyboard() *input.Keyboard {
 	return e.keyboard
 }
 
-// GetTicks returns number of milliseconds since SDL was initialized in NewEngine function
+// GetGamepads returns Engine instance of input.Gamepads, the only initialized instance you should use
+func (e *Engine) GetGamepads() *input.Gamepads {
+	return e.gamepads
+}
+
+// GetTextInput returns Engine instance of input.TextInput, the only initialized instance you should use
+func (e *Engine) GetTextInput() *input.TextInput {
+	return e.textInput
+}
+
+// GetActionMap returns Engine instance of input.ActionMap, which is updated every frame after events are handled
+func (e *Engine) GetActionMap() *input.ActionMap {
+	return e.actions
+}
+
+// GetTicks returns number of milliseconds since SDL was initialized in NewEngine function.
+// In headless mode returns virtual time, which is advanced only by Step and Run.
 func (e *Engine) GetTicks() uint64 {
+	if e.headless {
+		return e.virtualTicks
+	}
 	return sdl.GetTicks64()
 }
 
-// GetDeltaTime returns difference between two frames in milliseconds.
+// GetDeltaTime returns difference between two frames in milliseconds, see GetDeltaSeconds for higher precision.
 func (e *Engine) GetDeltaTime() uint64 {
 	return e.deltaTime
 }
 
 // Run creates window and start rendering activeScene.
 //
-// It is required to call Run in main thread
+// It is required to call Run in main thread.
+// In headless mode Run advances time by fixed interval each frame (based on TargetFPS, 60 FPS if not set),
+// and returns instead of exiting process when Exit is called.
 func (e *Engine) Run() {
 	if e.started {
 		return
@@ -232,64 +330,183 @@ func (e *Engine) Run() {
 	e.running = true
 	e.started = true
 
-	for e.running {
-		// Handle events
-		{
-			iters := 0
-			for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
-				iters += 1
+	// Exit may be called before Run, e.g. by setup code, then engine is closed without rendering any frame
+	for e.running && !e.exitRequested {
+		if e.headless {
+			e.virtualTicks += e.headlessFrameTime()
+			e.frame()
+			continue
+		}
 
-				//TODO:
-				switch event.(type) {
-				case *sdl.QuitEvent:
-					e.running = false
+		e.frame()
+		e.limitFrameRate()
+	}
 
-				case *sdl.MouseButtonEvent:
-					e.GetMouse().SetLastEvent(event.(*sdl.MouseButtonEvent))
+	e.Close()
+	if !e.headless {
+		os.Exit(e.exitCode)
+	}
+}
 
-				case *sdl.KeyboardEvent:
-					e.GetKeyboard().SetLastEvent(event.(*sdl.KeyboardEvent))
+// frame handles pending events, updates and renders active scene.
+func (e *Engine) frame() {
+	// Handle events
+	{
+		// all pending events are handled, so input state of the frame is complete
+		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+			//TODO:
+			switch event.(type) {
+			case *sdl.QuitEvent:
+				e.running = false
 
-				}
+			case *sdl.MouseButtonEvent:
+				e.GetMouse().SetLastEvent(event.(*sdl.MouseButtonEvent))
+
+			case *sdl.MouseWheelEvent:
+				e.GetMouse().HandleWheelEvent(event.(*sdl.MouseWheelEvent))
+
+			case *sdl.MouseMotionEvent:
+				e.GetMouse().HandleMotionEvent(event.(*sdl.MouseMotionEvent))
+
+			case *sdl.KeyboardEvent:
+				e.GetKeyboard().SetLastEvent(event.(*sdl.KeyboardEvent))
+
+			case *sdl.TextInputEvent:
+				e.textInput.HandleTextInputEvent(event.(*sdl.TextInputEvent))
+
+			case *sdl.TextEditingEvent:
+				e.textInput.HandleTextEditingEvent(event.(*sdl.TextEditingEvent))
+
+			case *sdl.ControllerDeviceEvent:
+				e.gamepads.HandleDeviceEvent(event.(*sdl.ControllerDeviceEvent))
+
+			case *sdl.ControllerButtonEvent:
+				e.gamepads.HandleButtonEvent(event.(*sdl.ControllerButtonEvent))
+
+			case *sdl.ControllerAxisEvent:
+				e.gamepads.HandleAxisEvent(event.(*sdl.ControllerAxisEvent))
 
-				if iters >= e.maxEventsPolledPerRender {
-					break
-				}
 			}
 		}
 
-		// Render
-		{
-			curTicks := e.GetTicks()
-			e.deltaTime = curTicks - e.previousTicks
-			e.previousTicks = curTicks
+		e.actions.Update()
+	}
+
+	// Render
+	{
+		e.updateTime()
+
+		updateStart := realTime()
+
+		if e.activeScene == nil {
+			if !e.activeSceneNoFunctionReported {
+				fmt.Println(fmt.Errorf("no active scene set"))
+				e.activeSceneNoFunctionReported = true
+			}
+		} else {
+			e.runFixedUpdates()
 
-			if e.activeScene.GetUpdateFunction() != nil {
+			if e.activeScene == nil {
+				// scene was popped by fixed update function
+			} else if e.activeScene.GetUpdateFunction() != nil {
 				e.activeScene.GetUpdateFunction()()
 			} else if !e.activeSceneNoFunctionReported {
 				fmt.Println(fmt.Errorf("no update function on scene ID=(%d)", e.activeScene.GetID()))
 				e.activeSceneNoFunctionReported = true
 			}
+		}
+
+		if e.activeScene != nil {
+			e.activeScene.GetScheduler().Update(e.deltaSeconds)
+		}
+		// scheduled functions may change active scene
+		if e.activeScene != nil {
+			e.updateComponents(e.activeScene, e.activeScene.GetAllNodes(), nil)
+		}
+		e.updateWorlds()
 
-			e.GetMouse().ApplyDeferred()
-			e.GetKeyboard().ApplyDeferred()
+		// input edges, scene manager and tweens advance every frame, even without active scene,
+		// so first frame of scene set later does not see input of previous frames
+		e.GetMouse().ApplyDeferred()
+		e.GetKeyboard().ApplyDeferred()
+		e.gamepads.ApplyDeferred()
+		e.textInput.ApplyDeferred()
 
-			nodes := e.activeScene.GetAllNodes()
+		renderStart := realTime()
 
-			color := e.GetActiveScene().GetBackgroundColor()
-			e.renderer.SetDrawColor(color.R, color.G, color.B, color.A)
-			e.renderer.Clear()
-			e.render(nodes)
-			e.renderer.Present()
+		e.sceneManager.advance(e.deltaSeconds)
+		e.tweens.Update(e.deltaSeconds)
+		if e.activeScene != nil {
+			e.updateAnimations(e.activeScene.GetAllNodes())
 		}
+		e.updateCameras()
+		e.renderFrame()
+
+		e.frameStats.record(e.deltaSeconds, renderStart-updateStart, realTime()-renderStart)
 	}
+}
 
-	e.cleanUp()
-	os.Exit(e.exitCode)
+// renderFrame renders scenes of scene manager, or transition between them, and presents the frame.
+func (e *Engine) renderFrame() {
+	e.renderer.SetClip(nil)
+
+	if e.activeScene == nil {
+		e.renderer.Clear(primitive.Color{R: 0, G: 0, B: 0, A: 255})
+	} else if t := e.sceneManager.transition; t != nil {
+		e.renderer.Clear(primitive.Color{R: 0, G: 0, B: 0, A: 255})
+		t.transition.Render(
+			e.renderer,
+			t.elapsed/t.transition.GetDuration(),
+			func(offset basic.Point) {
+				if t.from != nil {
+					e.renderScene(t.from, offset)
+				}
+			},
+			func(offset basic.Point) {
+				e.renderScene(e.activeScene, offset)
+			},
+		)
+	} else {
+		scenes := e.sceneManager.renderedScenes()
+		e.renderer.Clear(scenes[0].GetBackgroundColor())
+		core.DrawScene(e.renderer, scenes[0], basic.Point{})
+		for _, scene := range scenes[1:] {
+			e.renderScene(scene, basic.Point{})
+		}
+	}
+
+	e.renderer.Present()
 }
 
 // Exit tries to gracefully shutdown game engine and exit with provided code.
 func (e *Engine) Exit(code int) {
 	e.exitCode = code
+	e.exitRequested = true
 	e.running = false
 }
+
+// GetExitCode returns code provided to Exit, 0 if Exit was not called.
+func (e *Engine) GetExitCode() int {
+	return e.exitCode
+}
+
+// AddExitHook adds function called once when engine is closed, after Exit is called or window is closed,
+// e.g. to autosave game. Hooks are called in order they were added, before resources are released.
+func (e *Engine) AddExitHook(hook func()) {
+	e.exitHooks = append(e.exitHooks, hook)
+}
+
+// Close releases window, renderer and shuts down SDL. Run calls Close automatically,
+// so it is only needed when engine is driven by Step. Exit hooks are called before releasing resources.
+// Engine cannot be used after Close.
+func (e *Engine) Close() {
+	if e.closed {
+		return
+	}
+	e.closed = true
+	for _, hook := range e.exitHooks {
+		hook()
+	}
+	e.gamepads.Close()
+	e.cleanUp()
+}
@@ -0,0 +1,155 @@
+package goplayengine
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/core"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"github.com/SemyonHoyrish/GoPlayEngine/resource"
+	"github.com/SemyonHoyrish/GoPlayEngine/snapshot"
+	"image"
+	"testing"
+)
+
+// Golden images are stored in testdata/render, set GOPLAYENGINE_UPDATE_GOLDEN=1 to update them after
+// intended change of rendering.
+
+var (
+	testBackground = primitive.Color{R: 20, G: 20, B: 40, A: 255}
+	testRed        = primitive.Color{R: 230, G: 40, B: 40, A: 255}
+	testGreen      = primitive.Color{R: 40, G: 220, B: 80, A: 255}
+	testWhite      = primitive.Color{R: 255, G: 255, B: 255, A: 255}
+)
+
+var testBackends = map[string]RendererBackend{
+	"sdl":      RendererBackendSDL,
+	"software": RendererBackendSoftware,
+}
+
+// renderTestScene renders one frame of scene with nodes in headless engine of 64x48 pixels.
+func renderTestScene(t *testing.T, backend RendererBackend, nodes ...*core.Node) *image.RGBA {
+	t.Helper()
+
+	config := DefaultEngineConfig()
+	config.Headless = true
+	config.Width = 64
+	config.Height = 48
+	config.Backend = backend
+
+	engine, err := NewEngineWithConfig(config)
+	if err != nil {
+		t.Fatalf("cannot create engine: %v", err)
+	}
+	defer engine.Close()
+
+	scene := core.NewScene()
+	scene.SetUpdateFunction(func() {})
+	scene.SetBackgroundColor(testBackground)
+	for _, node := range nodes {
+		scene.AddNode(node)
+	}
+	engine.SetActiveScene(scene)
+
+	if err := engine.Step(16); err != nil {
+		t.Fatalf("cannot step engine: %v", err)
+	}
+	frame, err := engine.CaptureFrame()
+	if err != nil {
+		t.Fatalf("cannot capture frame: %v", err)
+	}
+	return frame
+}
+
+func newTestNode(texture *core.Texture, position basic.Point) *core.Node {
+	node := core.NewObjectNode(texture)
+	node.SetPosition(position)
+	return node
+}
+
+func TestRenderGolden(t *testing.T) {
+	cases := []struct {
+		name  string
+		nodes func() []*core.Node
+	}{
+		{"rectangle", func() []*core.Node {
+			rect := primitive.Rectangle{Width: 30, Height: 16, Color: testRed}
+			return []*core.Node{newTestNode(core.NewTextureFromPrimitive(rect), basic.Point{X: 32, Y: 24})}
+		}},
+		{"circle", func() []*core.Node {
+			circle := primitive.Circle{Radius: 12, Color: testGreen}
+			return []*core.Node{newTestNode(core.NewTextureFromPrimitive(circle), basic.Point{X: 32, Y: 24})}
+		}},
+		{"line", func() []*core.Node {
+			line := primitive.Line{To: basic.Point{X: 40, Y: 24}, Color: testWhite}
+			return []*core.Node{newTestNode(core.NewTextureFromPrimitive(line), basic.Point{X: 32, Y: 24})}
+		}},
+		{"image", func() []*core.Node {
+			node := newTestNode(core.NewTextureFromImage(resource.NewImage("testdata/assets/gradient.png")), basic.Point{X: 32, Y: 24})
+			node.SetOverrideSize(basic.Size{Width: 24, Height: 24})
+			return []*core.Node{node}
+		}},
+		{"text", func() []*core.Node {
+			node := core.NewTextNode(&core.NodeTextInfo{
+				Text:     "GoPlay",
+				TextSize: 14,
+				Font:     resource.NewFont("testdata/assets/DejaVuSansMono.ttf"),
+				Color:    testWhite,
+			})
+			node.SetPosition(basic.Point{X: 32, Y: 24})
+			return []*core.Node{node}
+		}},
+		{"text_color", func() []*core.Node {
+			// text is rasterized in white and multiplied by color, including its alpha, when drawn
+			node := core.NewTextNode(&core.NodeTextInfo{
+				Text:     "GoPlay",
+				TextSize: 14,
+				Font:     resource.NewFont("testdata/assets/DejaVuSansMono.ttf"),
+				Color:    primitive.Color{R: 230, G: 120, B: 40, A: 180},
+			})
+			node.SetPosition(basic.Point{X: 32, Y: 24})
+			return []*core.Node{node}
+		}},
+	}
+
+	for _, c := range cases {
+		for backendName, backend := range testBackends {
+			t.Run(c.name+"/"+backendName, func(t *testing.T) {
+				frame := renderTestScene(t, backend, c.nodes()...)
+				golden := fmt.Sprintf("testdata/render/%s_%s.png", c.name, backendName)
+				snapshot.Assert(t, frame, golden, snapshot.Options{Tolerance: 2})
+			})
+		}
+	}
+}
+
+// TestRenderCircleCentered checks that circle is centered at node position, it was drawn with its center
+// in the top-left corner of the node before.
+func TestRenderCircleCentered(t *testing.T) {
+	for backendName, backend := range testBackends {
+		t.Run(backendName, func(t *testing.T) {
+			circle := primitive.Circle{Radius: 12, Color: testGreen}
+			frame := renderTestScene(t, backend, newTestNode(core.NewTextureFromPrimitive(circle), basic.Point{X: 32, Y: 24}))
+
+			checks := []struct {
+				x, y  int
+				color primitive.Color
+			}{
+				{32, 24, testGreen},
+				{32 - 11, 24, testGreen},
+				{32 + 10, 24, testGreen},
+				{32, 24 - 11, testGreen},
+				{32, 24 + 10, testGreen},
+				// corners of node bounds are outside of the circle
+				{32 - 11, 24 - 11, testBackground},
+				{32 + 10, 24 + 10, testBackground},
+			}
+			for _, check := range checks {
+				c := frame.RGBAAt(check.x, check.y)
+				got := primitive.Color{R: c.R, G: c.G, B: c.B, A: c.A}
+				if got != check.color {
+					t.Errorf("pixel (%d, %d) is %v, expected %v", check.x, check.y, got, check.color)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package goplayengine
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// frameStatsWindow is a number of last frames used to calculate FrameStats.
+const frameStatsWindow = 120
+
+// FrameStats describes timing of recently rendered frames, see Engine.GetFrameStats.
+//
+// Except FrameCount and CurrentFPS, values are calculated over last frames (up to 120).
+// Frame time is time between starts of consecutive frames, including time spent waiting for TargetFPS and vsync.
+type FrameStats struct {
+	// FrameCount is a number of frames rendered since engine start.
+	FrameCount uint64
+
+	// CurrentFPS is calculated from time of the last frame.
+	CurrentFPS float64
+	// AverageFPS is calculated from average frame time.
+	AverageFPS float64
+
+	MinFrameTime     time.Duration
+	MaxFrameTime     time.Duration
+	AverageFrameTime time.Duration
+	// P50FrameTime, P95FrameTime and P99FrameTime are percentiles of frame time,
+	// e.g. 95% of frames were rendered in P95FrameTime or faster.
+	P50FrameTime time.Duration
+	P95FrameTime time.Duration
+	P99FrameTime time.Duration
+
+	// UpdateTime is an average time spent in update functions (including fixed updates).
+	UpdateTime time.Duration
+	// RenderTime is an average time spent on rendering scene.
+	RenderTime time.Duration
+}
+
+// frameStatsRecorder keeps timings of last frames in ring buffers.
+type frameStatsRecorder struct {
+	frameCount uint64
+
+	frameTimes  [frameStatsWindow]float64
+	updateTimes [frameStatsWindow]float64
+	renderTimes [frameStatsWindow]float64
+	next        int
+	filled      int
+}
+
+func newFrameStatsRecorder() *frameStatsRecorder {
+	return &frameStatsRecorder{}
+}
+
+// record adds timings of a frame, all values are in seconds.
+func (r *frameStatsRecorder) record(frameTime float64, updateTime float64, renderTime float64) {
+	r.frameCount++
+
+	r.frameTimes[r.next] = frameTime
+	r.updateTimes[r.next] = updateTime
+	r.renderTimes[r.next] = renderTime
+	r.next = (r.next + 1) % frameStatsWindow
+	if r.filled < frameStatsWindow {
+		r.filled++
+	}
+}
+
+func (r *frameStatsRecorder) stats() FrameStats {
+	stats := FrameStats{FrameCount: r.frameCount}
+	if r.filled == 0 {
+		return stats
+	}
+
+	last := r.frameTimes[(r.next+frameStatsWindow-1)%frameStatsWindow]
+	if last > 0 {
+		stats.CurrentFPS = 1 / last
+	}
+
+	sorted := make([]float64, r.filled)
+	copy(sorted, r.frameTimes[:r.filled])
+	sort.Float64s(sorted)
+
+	var frameSum, updateSum, renderSum float64
+	for i := 0; i < r.filled; i++ {
+		frameSum += r.frameTimes[i]
+		updateSum += r.updateTimes[i]
+		renderSum += r.renderTimes[i]
+	}
+	n := float64(r.filled)
+
+	if frameSum > 0 {
+		stats.AverageFPS = n / frameSum
+	}
+	stats.MinFrameTime = toDuration(sorted[0])
+	stats.MaxFrameTime = toDuration(sorted[r.filled-1])
+	stats.AverageFrameTime = toDuration(frameSum / n)
+	stats.P50FrameTime = toDuration(percentile(sorted, 0.50))
+	stats.P95FrameTime = toDuration(percentile(sorted, 0.95))
+	stats.P99FrameTime = toDuration(percentile(sorted, 0.99))
+	stats.UpdateTime = toDuration(updateSum / n)
+	stats.RenderTime = toDuration(renderSum / n)
+
+	return stats
+}
+
+// GetFrameStats returns timing statistics of recently rendered frames.
+func (e *Engine) GetFrameStats() FrameStats {
+	return e.frameStats.stats()
+}
+
+// percentile returns value below which p part of sorted values are, using nearest-rank method.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+func toDuration(seconds float64) time.Duration {
+	return time.Duration(math.Round(seconds * float64(time.Second)))
+}
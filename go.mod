@@ -3,3 +3,8 @@ module github.com/SemyonHoyrish/GoPlayEngine
 go 1.23.4
 
 require github.com/veandco/go-sdl2 v0.4.40
+
+require (
+	golang.org/x/image v0.30.0
+	golang.org/x/text v0.28.0 // indirect
+)
@@ -0,0 +1,102 @@
+package goplayengine
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/render"
+	"github.com/SemyonHoyrish/GoPlayEngine/render/software"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// newHeadlessEngine creates Engine which renders into offscreen software surface, see EngineConfig.Headless.
+func newHeadlessEngine(config EngineConfig) (*Engine, error) {
+	if err := sdl.Init(config.InitFlags &^ sdl.INIT_VIDEO); err != nil {
+		return nil, fmt.Errorf("cannot initialize sdl: %w", err)
+	}
+
+	if err := ttf.Init(); err != nil {
+		sdl.Quit()
+		return nil, fmt.Errorf("cannot initialize ttf: %w", err)
+	}
+
+	var renderer render.Renderer
+	var surf *sdl.Surface
+
+	switch config.Backend {
+	case RendererBackendSDL:
+		var err error
+		surf, err = sdl.CreateRGBSurfaceWithFormat(0, config.Width, config.Height, 32, uint32(sdl.PIXELFORMAT_RGBA32))
+		if err != nil {
+			ttf.Quit()
+			sdl.Quit()
+			return nil, fmt.Errorf("cannot create offscreen surface: %w", err)
+		}
+
+		r, err := sdl.CreateSoftwareRenderer(surf)
+		if err != nil {
+			surf.Free()
+			ttf.Quit()
+			sdl.Quit()
+			return nil, fmt.Errorf("cannot create software renderer: %w", err)
+		}
+		renderer = render.NewSDLRenderer(r)
+	case RendererBackendSoftware:
+		renderer = software.NewRenderer(int(config.Width), int(config.Height))
+	default:
+		ttf.Quit()
+		sdl.Quit()
+		return nil, fmt.Errorf("invalid engine config: unknown renderer backend %d", config.Backend)
+	}
+
+	engine := newEngine(config, renderer)
+	engine.headless = true
+	engine.surface = surf
+
+	engine.cleanUp = func() {
+		engine.renderer.Destroy()
+		if engine.surface != nil {
+			engine.surface.Free()
+		}
+		ttf.Quit()
+		sdl.Quit()
+	}
+
+	return engine, nil
+}
+
+// IsHeadless returns true if engine was created with EngineConfig.Headless.
+func (e *Engine) IsHeadless() bool {
+	return e.headless
+}
+
+// Step advances virtual time by dt milliseconds, and then handles events, updates and renders active scene once.
+// Result of each Step depends only on dt and state of the scene, so any number of frames can be simulated
+// deterministically.
+//
+// Step is available only in headless mode and cannot be used together with Run.
+func (e *Engine) Step(dt uint64) error {
+	if !e.headless {
+		return fmt.Errorf("step is available only in headless mode")
+	}
+	if e.closed {
+		return fmt.Errorf("engine is closed")
+	}
+	if e.started {
+		return fmt.Errorf("cannot step engine, which is started with Run")
+	}
+
+	e.running = true
+	e.virtualTicks += dt
+	e.frame()
+
+	return nil
+}
+
+// headlessFrameTime returns number of milliseconds virtual time is advanced each frame when headless engine is Run.
+// It is at least 1 millisecond, so virtual time advances even if TargetFPS is above 1000.
+func (e *Engine) headlessFrameTime() uint64 {
+	if e.config.TargetFPS > 0 {
+		return uint64(max(1000/e.config.TargetFPS, 1))
+	}
+	return 1000 / 60
+}
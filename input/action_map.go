@@ -0,0 +1,447 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/veandco/go-sdl2/sdl"
+	"math"
+	"os"
+	"sort"
+)
+
+// actionPressThreshold is a value of analog binding (gamepad axis) above which action is considered pressed.
+const actionPressThreshold = 0.5
+
+// actionMapVersion is a version of bindings file written by ActionMap.Save.
+const actionMapVersion = 1
+
+// AxisBinding is a pair of bindings of 1D axis action, value of the axis is Positive minus Negative.
+type AxisBinding struct {
+	Negative Binding `json:"negative"`
+	Positive Binding `json:"positive"`
+}
+
+// VectorBinding is a composite of four bindings of 2D axis action, e.g. WASD keys or gamepad stick.
+type VectorBinding struct {
+	Left  Binding `json:"left"`
+	Right Binding `json:"right"`
+	Up    Binding `json:"up"`
+	Down  Binding `json:"down"`
+}
+
+// actionBindings are bindings of one action, stored in bindings file.
+type actionBindings struct {
+	Buttons []Binding       `json:"buttons,omitempty"`
+	Axes    []AxisBinding   `json:"axes,omitempty"`
+	Vectors []VectorBinding `json:"vectors,omitempty"`
+}
+
+type action struct {
+	bindings actionBindings
+
+	pressed      bool
+	justPressed  bool
+	justReleased bool
+}
+
+// ActionMap maps named actions (e.g. "jump", "move") to keyboard keys, mouse buttons and gamepad buttons and axes,
+// so game code does not depend on concrete inputs, and inputs can be rebound by player.
+// Action can be used as a button (Pressed, JustPressed, JustReleased), 1D axis (Axis) or 2D axis (Vector),
+// depending on its bindings.
+//
+// It is required to use instance initialized by Engine (Engine.GetActionMap), which is updated every frame.
+type ActionMap struct {
+	keyboard *Keyboard
+	mouse    *Mouse
+	gamepads *Gamepads
+	// index of gamepad used by the map, -1 to use all connected gamepads
+	gamepadIndex int
+
+	actions map[string]*action
+
+	listener      func(b Binding)
+	listenIgnored map[Binding]bool
+}
+
+// NewActionMap initialize new ActionMap object reading provided devices, should be called only once (done inside Engine)
+func NewActionMap(keyboard *Keyboard, mouse *Mouse, gamepads *Gamepads) *ActionMap {
+	return &ActionMap{
+		keyboard:     keyboard,
+		mouse:        mouse,
+		gamepads:     gamepads,
+		gamepadIndex: -1,
+		actions:      make(map[string]*action),
+	}
+}
+
+// SetGamepadIndex sets index of gamepad, which inputs are used by the map, -1 (default) to use all connected gamepads.
+func (m *ActionMap) SetGamepadIndex(index int) {
+	m.gamepadIndex = max(-1, index)
+}
+
+// GetGamepadIndex returns index of gamepad used by the map, -1 if all connected gamepads are used.
+func (m *ActionMap) GetGamepadIndex() int {
+	return m.gamepadIndex
+}
+
+func (m *ActionMap) getAction(name string) *action {
+	a, ok := m.actions[name]
+	if !ok {
+		a = &action{}
+		m.actions[name] = a
+	}
+	return a
+}
+
+// Bind adds button bindings to action, action is pressed while any of them is pressed.
+func (m *ActionMap) Bind(name string, bindings ...Binding) {
+	a := m.getAction(name)
+	a.bindings.Buttons = append(a.bindings.Buttons, bindings...)
+}
+
+// BindAxis adds pair of bindings to 1D axis action, e.g. keys A and D for "move_x".
+func (m *ActionMap) BindAxis(name string, negative Binding, positive Binding) {
+	a := m.getAction(name)
+	a.bindings.Axes = append(a.bindings.Axes, AxisBinding{Negative: negative, Positive: positive})
+}
+
+// BindVector adds composite of four bindings to 2D axis action, e.g. keys WASD for "move".
+func (m *ActionMap) BindVector(name string, left Binding, right Binding, up Binding, down Binding) {
+	a := m.getAction(name)
+	a.bindings.Vectors = append(a.bindings.Vectors, VectorBinding{Left: left, Right: right, Up: up, Down: down})
+}
+
+// BindStick adds left (or right if left is false) stick of gamepad to 2D axis action.
+func (m *ActionMap) BindStick(name string, left bool) {
+	axisX, axisY := GamepadAxisLeftX, GamepadAxisLeftY
+	if !left {
+		axisX, axisY = GamepadAxisRightX, GamepadAxisRightY
+	}
+	m.BindVector(name,
+		BindGamepadAxis(axisX, -1), BindGamepadAxis(axisX, 1),
+		BindGamepadAxis(axisY, -1), BindGamepadAxis(axisY, 1),
+	)
+}
+
+// Rebind replaces binding old with binding new in all bindings of action (buttons, axes and vectors),
+// returns false if action has no such binding.
+func (m *ActionMap) Rebind(name string, old Binding, new Binding) bool {
+	a, ok := m.actions[name]
+	if !ok {
+		return false
+	}
+
+	replace := func(b *Binding) bool {
+		if *b == old {
+			*b = new
+			return true
+		}
+		return false
+	}
+
+	replaced := false
+	for i := range a.bindings.Buttons {
+		replaced = replace(&a.bindings.Buttons[i]) || replaced
+	}
+	for i := range a.bindings.Axes {
+		replaced = replace(&a.bindings.Axes[i].Negative) || replaced
+		replaced = replace(&a.bindings.Axes[i].Positive) || replaced
+	}
+	for i := range a.bindings.Vectors {
+		v := &a.bindings.Vectors[i]
+		replaced = replace(&v.Left) || replaced
+		replaced = replace(&v.Right) || replaced
+		replaced = replace(&v.Up) || replaced
+		replaced = replace(&v.Down) || replaced
+	}
+	return replaced
+}
+
+// Unbind removes all bindings of action.
+func (m *ActionMap) Unbind(name string) {
+	delete(m.actions, name)
+}
+
+// GetBindings returns button bindings of action.
+func (m *ActionMap) GetBindings(name string) []Binding {
+	if a, ok := m.actions[name]; ok {
+		return append([]Binding(nil), a.bindings.Buttons...)
+	}
+	return nil
+}
+
+// GetAxisBindings returns 1D axis bindings of action.
+func (m *ActionMap) GetAxisBindings(name string) []AxisBinding {
+	if a, ok := m.actions[name]; ok {
+		return append([]AxisBinding(nil), a.bindings.Axes...)
+	}
+	return nil
+}
+
+// GetVectorBindings returns 2D axis bindings of action.
+func (m *ActionMap) GetVectorBindings(name string) []VectorBinding {
+	if a, ok := m.actions[name]; ok {
+		return append([]VectorBinding(nil), a.bindings.Vectors...)
+	}
+	return nil
+}
+
+// GetActions returns names of actions with bindings sorted alphabetically.
+func (m *ActionMap) GetActions() []string {
+	names := make([]string, 0, len(m.actions))
+	for name := range m.actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Value returns value of button bindings of action from 0 to 1, maximum of its bindings.
+// Keys and buttons have value 1 when pressed, gamepad axes have value of their direction with applied dead zone.
+func (m *ActionMap) Value(name string) float32 {
+	a, ok := m.actions[name]
+	if !ok {
+		return 0
+	}
+
+	var value float32
+	for _, b := range a.bindings.Buttons {
+		value = max(value, m.GetBindingValue(b))
+	}
+	return value
+}
+
+// Pressed returns true if any button binding of action is pressed.
+func (m *ActionMap) Pressed(name string) bool {
+	a, ok := m.actions[name]
+	return ok && a.pressed
+}
+
+// JustPressed returns true if action became pressed during last frame.
+func (m *ActionMap) JustPressed(name string) bool {
+	a, ok := m.actions[name]
+	return ok && a.justPressed
+}
+
+// JustReleased returns true if action became released during last frame.
+// Action pressed and released during the same frame is reported by both JustPressed and JustReleased.
+func (m *ActionMap) JustReleased(name string) bool {
+	a, ok := m.actions[name]
+	return ok && a.justReleased
+}
+
+// Axis returns value of 1D axis action from -1 to 1, sum of its axis bindings.
+func (m *ActionMap) Axis(name string) float32 {
+	a, ok := m.actions[name]
+	if !ok {
+		return 0
+	}
+
+	var value float32
+	for _, b := range a.bindings.Axes {
+		value += m.GetBindingValue(b.Positive) - m.GetBindingValue(b.Negative)
+	}
+	return max(-1, min(1, value))
+}
+
+// Vector returns value of 2D axis action, sum of its vector bindings with length limited to 1,
+// so diagonal movement is not faster. Right and down are positive directions.
+func (m *ActionMap) Vector(name string) basic.Point {
+	a, ok := m.actions[name]
+	if !ok {
+		return basic.Point{}
+	}
+
+	var v basic.Point
+	for _, b := range a.bindings.Vectors {
+		v.X += m.GetBindingValue(b.Right) - m.GetBindingValue(b.Left)
+		v.Y += m.GetBindingValue(b.Down) - m.GetBindingValue(b.Up)
+	}
+
+	length := float32(math.Hypot(float64(v.X), float64(v.Y)))
+	if length > 1 {
+		v.X /= length
+		v.Y /= length
+	}
+	return v
+}
+
+// GetBindingValue returns current value of binding from 0 to 1.
+func (m *ActionMap) GetBindingValue(b Binding) float32 {
+	switch b.Type {
+	case BindingKey:
+		if m.keyboard.ButtonPressed(b.Key) {
+			return 1
+		}
+	case BindingMouseButton:
+		if m.mouse.ButtonPressed(b.MouseButton) {
+			return 1
+		}
+	case BindingGamepadButton:
+		for _, g := range m.getGamepads() {
+			if g.ButtonPressed(b.GamepadButton) {
+				return 1
+			}
+		}
+	case BindingGamepadAxis:
+		var value float32
+		for _, g := range m.getGamepads() {
+			value = max(value, g.GetAxis(b.GamepadAxis)*float32(b.Direction))
+		}
+		return value
+	}
+	return 0
+}
+
+// getBindingEdges returns true if button of binding was pressed or released during last frame,
+// axes have no edges.
+func (m *ActionMap) getBindingEdges(b Binding) (pressed bool, released bool) {
+	switch b.Type {
+	case BindingKey:
+		return m.keyboard.JustPressed(b.Key), m.keyboard.JustReleased(b.Key)
+	case BindingMouseButton:
+		return m.mouse.JustPressed(b.MouseButton), m.mouse.JustReleased(b.MouseButton)
+	case BindingGamepadButton:
+		for _, g := range m.getGamepads() {
+			pressed = pressed || g.ButtonDown(b.GamepadButton)
+			released = released || g.ButtonUp(b.GamepadButton)
+		}
+	}
+	return pressed, released
+}
+
+func (m *ActionMap) getGamepads() []*Gamepad {
+	if m.gamepadIndex < 0 {
+		return m.gamepads.GetConnected()
+	}
+	if g := m.gamepads.Get(m.gamepadIndex); g.IsConnected() {
+		return []*Gamepad{g}
+	}
+	return nil
+}
+
+// ListenForBinding makes map call callback once with the next pressed input, e.g. to rebind action in options menu.
+// Inputs, which are pressed when listening starts, are ignored until released.
+func (m *ActionMap) ListenForBinding(callback func(b Binding)) {
+	m.listener = callback
+	m.listenIgnored = make(map[Binding]bool)
+	for _, b := range m.allInputs() {
+		if m.GetBindingValue(b) > actionPressThreshold {
+			m.listenIgnored[b] = true
+		}
+	}
+}
+
+// StopListening cancels ListenForBinding.
+func (m *ActionMap) StopListening() {
+	m.listener = nil
+	m.listenIgnored = nil
+}
+
+// IsListening returns true if map waits for input started with ListenForBinding.
+func (m *ActionMap) IsListening() bool {
+	return m.listener != nil
+}
+
+// allInputs returns bindings of every input which can be detected by ListenForBinding.
+func (m *ActionMap) allInputs() []Binding {
+	inputs := make([]Binding, 0, sdl.NUM_SCANCODES+16)
+	for key := Scancode(1); key < sdl.NUM_SCANCODES; key++ {
+		inputs = append(inputs, BindKey(key))
+	}
+	for btn := range mouseButtonNames {
+		inputs = append(inputs, BindMouseButton(btn))
+	}
+	for btn := GamepadButton(0); btn < gamepadButtonCount; btn++ {
+		inputs = append(inputs, BindGamepadButton(btn))
+	}
+	for axis := GamepadAxis(0); axis < gamepadAxisCount; axis++ {
+		inputs = append(inputs, BindGamepadAxis(axis, -1), BindGamepadAxis(axis, 1))
+	}
+	return inputs
+}
+
+// Update is an internal function, which updates pressed state of actions and detects input for ListenForBinding.
+// Should be called once per frame after events are handled.
+func (m *ActionMap) Update() {
+	for name, a := range m.actions {
+		previousPressed := a.pressed
+		a.pressed = len(a.bindings.Buttons) > 0 && m.Value(name) > actionPressThreshold
+
+		// edges of bindings are checked too, so button pressed and released during one frame is not missed
+		var bindingPressed, bindingReleased bool
+		for _, b := range a.bindings.Buttons {
+			pressed, released := m.getBindingEdges(b)
+			bindingPressed = bindingPressed || pressed
+			bindingReleased = bindingReleased || released
+		}
+		a.justPressed = !previousPressed && (a.pressed || bindingPressed)
+		a.justReleased = !a.pressed && (previousPressed || bindingPressed && bindingReleased)
+	}
+
+	if m.listener == nil {
+		return
+	}
+	for _, b := range m.allInputs() {
+		pressed := m.GetBindingValue(b) > actionPressThreshold
+		if !pressed {
+			delete(m.listenIgnored, b)
+			continue
+		}
+		if m.listenIgnored[b] {
+			continue
+		}
+
+		listener := m.listener
+		m.StopListening()
+		listener(b)
+		return
+	}
+}
+
+// bindingsFile is content of file written by ActionMap.Save.
+type bindingsFile struct {
+	Version int                       `json:"version"`
+	Actions map[string]actionBindings `json:"actions"`
+}
+
+// Save writes bindings of all actions into JSON file, e.g. to keep bindings changed in options menu.
+func (m *ActionMap) Save(path string) error {
+	data := bindingsFile{Version: actionMapVersion, Actions: make(map[string]actionBindings, len(m.actions))}
+	for name, a := range m.actions {
+		data.Actions[name] = a.bindings
+	}
+
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode bindings: %w", err)
+	}
+	if err := os.WriteFile(path, append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("cannot write bindings (%s): %w", path, err)
+	}
+	return nil
+}
+
+// Load reads bindings from JSON file written by Save. Bindings of actions present in the file replace current ones,
+// other actions keep their bindings, so actions added to the game after file was saved keep default bindings.
+// If file is invalid, no bindings are changed.
+func (m *ActionMap) Load(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read bindings (%s): %w", path, err)
+	}
+
+	var data bindingsFile
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("cannot decode bindings (%s): %w", path, err)
+	}
+	if data.Version <= 0 || data.Version > actionMapVersion {
+		return fmt.Errorf("cannot load bindings (%s): unsupported version %d", path, data.Version)
+	}
+
+	for name, bindings := range data.Actions {
+		m.getAction(name).bindings = bindings
+	}
+	return nil
+}
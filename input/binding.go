@@ -0,0 +1,163 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/veandco/go-sdl2/sdl"
+	"strings"
+)
+
+// BindingType describes source of input of Binding.
+type BindingType uint8
+
+// describes binding types
+const (
+	BindingNone BindingType = iota
+	BindingKey
+	BindingMouseButton
+	BindingGamepadButton
+	BindingGamepadAxis
+)
+
+// Binding is a single input bound to action of ActionMap, created with BindKey, BindMouseButton, BindGamepadButton
+// or BindGamepadAxis. Bindings are comparable, so they can be used as map keys and compared with ==.
+type Binding struct {
+	Type          BindingType
+	Key           Scancode
+	MouseButton   MouseButtonType
+	GamepadButton GamepadButton
+	GamepadAxis   GamepadAxis
+	// Direction of gamepad axis, 1 for positive values (right, down, pressed trigger) and -1 for negative values.
+	Direction int8
+}
+
+// BindKey creates binding of keyboard key.
+func BindKey(key Scancode) Binding {
+	return Binding{Type: BindingKey, Key: key}
+}
+
+// BindMouseButton creates binding of mouse button.
+func BindMouseButton(btn MouseButtonType) Binding {
+	return Binding{Type: BindingMouseButton, MouseButton: btn}
+}
+
+// BindGamepadButton creates binding of gamepad button.
+func BindGamepadButton(btn GamepadButton) Binding {
+	return Binding{Type: BindingGamepadButton, GamepadButton: btn}
+}
+
+// BindGamepadAxis creates binding of one direction of gamepad axis, direction is 1 for positive values
+// (right, down, pressed trigger) and -1 for negative values (left, up).
+func BindGamepadAxis(axis GamepadAxis, direction int8) Binding {
+	if direction < 0 {
+		direction = -1
+	} else {
+		direction = 1
+	}
+	return Binding{Type: BindingGamepadAxis, GamepadAxis: axis, Direction: direction}
+}
+
+// String returns human-readable name of binding, e.g. to show it in options menu.
+func (b Binding) String() string {
+	switch b.Type {
+	case BindingKey:
+		return sdl.GetScancodeName(b.Key)
+	case BindingMouseButton:
+		return "Mouse " + mouseButtonNames[b.MouseButton]
+	case BindingGamepadButton:
+		return "Gamepad " + sdl.GameControllerGetStringForButton(b.GamepadButton)
+	case BindingGamepadAxis:
+		return "Gamepad " + sdl.GameControllerGetStringForAxis(b.GamepadAxis) + directionSign(b.Direction)
+	}
+	return ""
+}
+
+var mouseButtonNames = map[MouseButtonType]string{
+	MouseButtonLeft:   "left",
+	MouseButtonMiddle: "middle",
+	MouseButtonRight:  "right",
+	MouseButtonX1:     "x1",
+	MouseButtonX2:     "x2",
+}
+
+func directionSign(direction int8) string {
+	if direction < 0 {
+		return "-"
+	}
+	return "+"
+}
+
+// bindingJSON is stored representation of Binding, inputs are stored by names, so files are readable and editable.
+type bindingJSON struct {
+	Key           string `json:"key,omitempty"`
+	MouseButton   string `json:"mouseButton,omitempty"`
+	GamepadButton string `json:"gamepadButton,omitempty"`
+	GamepadAxis   string `json:"gamepadAxis,omitempty"`
+	Direction     int8   `json:"direction,omitempty"`
+}
+
+// MarshalJSON encodes binding with names of inputs, e.g. {"key": "Space"}.
+func (b Binding) MarshalJSON() ([]byte, error) {
+	var data bindingJSON
+	switch b.Type {
+	case BindingKey:
+		data.Key = sdl.GetScancodeName(b.Key)
+		if data.Key == "" {
+			return nil, fmt.Errorf("cannot encode binding of unnamed key %d", b.Key)
+		}
+	case BindingMouseButton:
+		name, ok := mouseButtonNames[b.MouseButton]
+		if !ok {
+			return nil, fmt.Errorf("cannot encode binding of unknown mouse button %d", b.MouseButton)
+		}
+		data.MouseButton = name
+	case BindingGamepadButton:
+		data.GamepadButton = sdl.GameControllerGetStringForButton(b.GamepadButton)
+	case BindingGamepadAxis:
+		data.GamepadAxis = sdl.GameControllerGetStringForAxis(b.GamepadAxis)
+		data.Direction = b.Direction
+	default:
+		return nil, fmt.Errorf("cannot encode empty binding")
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON decodes binding encoded by MarshalJSON.
+func (b *Binding) UnmarshalJSON(content []byte) error {
+	var data bindingJSON
+	if err := json.Unmarshal(content, &data); err != nil {
+		return err
+	}
+
+	switch {
+	case data.Key != "":
+		key := sdl.GetScancodeFromName(data.Key)
+		if key == SCANCODE_UNKNOWN {
+			return fmt.Errorf("unknown key %q", data.Key)
+		}
+		*b = BindKey(key)
+	case data.MouseButton != "":
+		for btn, name := range mouseButtonNames {
+			if strings.EqualFold(name, data.MouseButton) {
+				*b = BindMouseButton(btn)
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown mouse button %q", data.MouseButton)
+	case data.GamepadButton != "":
+		btn := sdl.GameControllerGetButtonFromString(data.GamepadButton)
+		if btn == sdl.CONTROLLER_BUTTON_INVALID {
+			return fmt.Errorf("unknown gamepad button %q", data.GamepadButton)
+		}
+		*b = BindGamepadButton(btn)
+	case data.GamepadAxis != "":
+		axis := sdl.GameControllerGetAxisFromString(data.GamepadAxis)
+		if axis == sdl.CONTROLLER_AXIS_INVALID {
+			return fmt.Errorf("unknown gamepad axis %q", data.GamepadAxis)
+		}
+		*b = BindGamepadAxis(axis, data.Direction)
+	default:
+		return fmt.Errorf("binding has no input")
+	}
+	return nil
+}
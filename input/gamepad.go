@@ -0,0 +1,272 @@
+package input
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/veandco/go-sdl2/sdl"
+	"math"
+)
+
+// GamepadButton is an alias made for consistency, see sdl.CONTROLLER_BUTTON_*.
+type GamepadButton = sdl.GameControllerButton
+
+// describes gamepad buttons, names follow Xbox controller layout
+const (
+	GamepadButtonA             GamepadButton = sdl.CONTROLLER_BUTTON_A
+	GamepadButtonB             GamepadButton = sdl.CONTROLLER_BUTTON_B
+	GamepadButtonX             GamepadButton = sdl.CONTROLLER_BUTTON_X
+	GamepadButtonY             GamepadButton = sdl.CONTROLLER_BUTTON_Y
+	GamepadButtonBack          GamepadButton = sdl.CONTROLLER_BUTTON_BACK
+	GamepadButtonGuide         GamepadButton = sdl.CONTROLLER_BUTTON_GUIDE
+	GamepadButtonStart         GamepadButton = sdl.CONTROLLER_BUTTON_START
+	GamepadButtonLeftStick     GamepadButton = sdl.CONTROLLER_BUTTON_LEFTSTICK
+	GamepadButtonRightStick    GamepadButton = sdl.CONTROLLER_BUTTON_RIGHTSTICK
+	GamepadButtonLeftShoulder  GamepadButton = sdl.CONTROLLER_BUTTON_LEFTSHOULDER
+	GamepadButtonRightShoulder GamepadButton = sdl.CONTROLLER_BUTTON_RIGHTSHOULDER
+	GamepadButtonDpadUp        GamepadButton = sdl.CONTROLLER_BUTTON_DPAD_UP
+	GamepadButtonDpadDown      GamepadButton = sdl.CONTROLLER_BUTTON_DPAD_DOWN
+	GamepadButtonDpadLeft      GamepadButton = sdl.CONTROLLER_BUTTON_DPAD_LEFT
+	GamepadButtonDpadRight     GamepadButton = sdl.CONTROLLER_BUTTON_DPAD_RIGHT
+)
+
+// GamepadAxis is an alias made for consistency, see sdl.CONTROLLER_AXIS_*.
+type GamepadAxis = sdl.GameControllerAxis
+
+// describes gamepad axes
+const (
+	GamepadAxisLeftX        GamepadAxis = sdl.CONTROLLER_AXIS_LEFTX
+	GamepadAxisLeftY        GamepadAxis = sdl.CONTROLLER_AXIS_LEFTY
+	GamepadAxisRightX       GamepadAxis = sdl.CONTROLLER_AXIS_RIGHTX
+	GamepadAxisRightY       GamepadAxis = sdl.CONTROLLER_AXIS_RIGHTY
+	GamepadAxisTriggerLeft  GamepadAxis = sdl.CONTROLLER_AXIS_TRIGGERLEFT
+	GamepadAxisTriggerRight GamepadAxis = sdl.CONTROLLER_AXIS_TRIGGERRIGHT
+)
+
+const (
+	gamepadButtonCount = sdl.CONTROLLER_BUTTON_MAX
+	gamepadAxisCount   = sdl.CONTROLLER_AXIS_MAX
+)
+
+// Default dead zones of gamepads, as fractions of full axis range.
+const (
+	DefaultStickDeadZone   float32 = 0.15
+	DefaultTriggerDeadZone float32 = 0.05
+)
+
+// Gamepad represent one game controller slot.
+// Gamepads are created by Gamepads (Engine.GetGamepads) and are kept after disconnect,
+// so same Gamepad is used again when controller is connected to its slot.
+type Gamepad struct {
+	index int
+
+	controller *sdl.GameController
+	instanceID sdl.JoystickID
+	name       string
+
+	buttons [gamepadButtonCount]bool
+	// buttons which changed state during last frame
+	buttonsDown [gamepadButtonCount]bool
+	buttonsUp   [gamepadButtonCount]bool
+
+	axes [gamepadAxisCount]int16
+
+	stickDeadZone   float32
+	triggerDeadZone float32
+}
+
+func newGamepad(index int) *Gamepad {
+	return &Gamepad{
+		index:           index,
+		stickDeadZone:   DefaultStickDeadZone,
+		triggerDeadZone: DefaultTriggerDeadZone,
+	}
+}
+
+// GetIndex returns index of slot of the gamepad, starting from 0.
+func (g *Gamepad) GetIndex() int {
+	return g.index
+}
+
+// IsConnected returns true if controller is connected to the gamepad slot.
+func (g *Gamepad) IsConnected() bool {
+	return g.controller != nil
+}
+
+// GetName returns name of connected controller, empty string if gamepad is disconnected.
+func (g *Gamepad) GetName() string {
+	return g.name
+}
+
+// ButtonPressed returns true if provided button is pressed and false otherwise.
+func (g *Gamepad) ButtonPressed(btn GamepadButton) bool {
+	return validButton(btn) && g.buttons[btn]
+}
+
+// ButtonDown returns true if provided button was pressed during last frame.
+func (g *Gamepad) ButtonDown(btn GamepadButton) bool {
+	return validButton(btn) && g.buttonsDown[btn]
+}
+
+// ButtonUp returns true if provided button was released during last frame.
+func (g *Gamepad) ButtonUp(btn GamepadButton) bool {
+	return validButton(btn) && g.buttonsUp[btn]
+}
+
+// GetAxis returns value of axis with applied dead zone, from -1 to 1 for sticks (down and right are positive),
+// and from 0 to 1 for triggers. Dead zone of stick axis is applied to the axis alone,
+// use GetLeftStick or GetRightStick to apply it to the whole stick.
+func (g *Gamepad) GetAxis(axis GamepadAxis) float32 {
+	if axis < 0 || axis >= gamepadAxisCount {
+		return 0
+	}
+
+	value := g.getRawAxis(axis)
+	if axis == GamepadAxisTriggerLeft || axis == GamepadAxisTriggerRight {
+		return applyDeadZone(value, g.triggerDeadZone)
+	}
+	if value < 0 {
+		return -applyDeadZone(-value, g.stickDeadZone)
+	}
+	return applyDeadZone(value, g.stickDeadZone)
+}
+
+// GetLeftStick returns position of left stick with applied dead zone, each coordinate is from -1 to 1.
+func (g *Gamepad) GetLeftStick() basic.Point {
+	return g.getStick(GamepadAxisLeftX, GamepadAxisLeftY)
+}
+
+// GetRightStick returns position of right stick with applied dead zone, each coordinate is from -1 to 1.
+func (g *Gamepad) GetRightStick() basic.Point {
+	return g.getStick(GamepadAxisRightX, GamepadAxisRightY)
+}
+
+// GetLeftTrigger returns value of left trigger with applied dead zone, from 0 to 1.
+func (g *Gamepad) GetLeftTrigger() float32 {
+	return g.GetAxis(GamepadAxisTriggerLeft)
+}
+
+// GetRightTrigger returns value of right trigger with applied dead zone, from 0 to 1.
+func (g *Gamepad) GetRightTrigger() float32 {
+	return g.GetAxis(GamepadAxisTriggerRight)
+}
+
+// SetStickDeadZone sets fraction of stick range (from 0 to 1) which is ignored, DefaultStickDeadZone by default.
+// Stick values outside of the dead zone are rescaled, so they still start from 0.
+func (g *Gamepad) SetStickDeadZone(deadZone float32) {
+	g.stickDeadZone = clampDeadZone(deadZone)
+}
+
+// GetStickDeadZone returns dead zone of sticks.
+func (g *Gamepad) GetStickDeadZone() float32 {
+	return g.stickDeadZone
+}
+
+// SetTriggerDeadZone sets fraction of trigger range (from 0 to 1) which is ignored, DefaultTriggerDeadZone by default.
+func (g *Gamepad) SetTriggerDeadZone(deadZone float32) {
+	g.triggerDeadZone = clampDeadZone(deadZone)
+}
+
+// GetTriggerDeadZone returns dead zone of triggers.
+func (g *Gamepad) GetTriggerDeadZone() float32 {
+	return g.triggerDeadZone
+}
+
+// HasRumble returns true if connected controller supports rumble.
+func (g *Gamepad) HasRumble() bool {
+	return g.controller != nil && g.controller.HasRumble()
+}
+
+// Rumble starts vibration of controller with strength of low and high frequency motors from 0 to 1 for duration
+// in seconds, replacing previous one. Rumble with zero strength stops vibration.
+// Returns error if gamepad is disconnected or controller does not support rumble.
+func (g *Gamepad) Rumble(low float32, high float32, duration float64) error {
+	if g.controller == nil {
+		return fmt.Errorf("cannot rumble disconnected gamepad (index=%d)", g.index)
+	}
+	if err := g.controller.Rumble(rumbleStrength(low), rumbleStrength(high), uint32(duration*1000)); err != nil {
+		return fmt.Errorf("cannot rumble gamepad (index=%d): %w", g.index, err)
+	}
+	return nil
+}
+
+func (g *Gamepad) getRawAxis(axis GamepadAxis) float32 {
+	value := float32(g.axes[axis]) / 32767
+	return max(-1, min(1, value))
+}
+
+// getStick returns stick position with radial dead zone, so diagonal movement is not snapped to axes.
+func (g *Gamepad) getStick(axisX GamepadAxis, axisY GamepadAxis) basic.Point {
+	x, y := g.getRawAxis(axisX), g.getRawAxis(axisY)
+	length := float32(math.Hypot(float64(x), float64(y)))
+	if length == 0 {
+		return basic.Point{}
+	}
+
+	scaled := min(1, applyDeadZone(length, g.stickDeadZone))
+	return basic.Point{X: x / length * scaled, Y: y / length * scaled}
+}
+
+func (g *Gamepad) connect(controller *sdl.GameController) {
+	g.controller = controller
+	g.instanceID = controller.Joystick().InstanceID()
+	g.name = controller.Name()
+	g.reset()
+}
+
+func (g *Gamepad) disconnect() {
+	if g.controller != nil {
+		g.controller.Close()
+	}
+	g.controller = nil
+	g.name = ""
+	g.reset()
+}
+
+// reset releases all buttons and axes.
+func (g *Gamepad) reset() {
+	for btn, pressed := range g.buttons {
+		if pressed {
+			g.buttonsUp[btn] = true
+		}
+	}
+	g.buttons = [gamepadButtonCount]bool{}
+	g.buttonsDown = [gamepadButtonCount]bool{}
+	g.axes = [gamepadAxisCount]int16{}
+}
+
+func (g *Gamepad) setButton(btn GamepadButton, pressed bool) {
+	if !validButton(btn) || g.buttons[btn] == pressed {
+		return
+	}
+	g.buttons[btn] = pressed
+	if pressed {
+		g.buttonsDown[btn] = true
+	} else {
+		g.buttonsUp[btn] = true
+	}
+}
+
+func (g *Gamepad) clearFrameEvents() {
+	g.buttonsDown = [gamepadButtonCount]bool{}
+	g.buttonsUp = [gamepadButtonCount]bool{}
+}
+
+func validButton(btn GamepadButton) bool {
+	return btn >= 0 && btn < gamepadButtonCount
+}
+
+// applyDeadZone maps value from [deadZone, 1] to [0, 1], values below dead zone are 0.
+func applyDeadZone(value float32, deadZone float32) float32 {
+	if value <= deadZone {
+		return 0
+	}
+	return min(1, (value-deadZone)/(1-deadZone))
+}
+
+func clampDeadZone(deadZone float32) float32 {
+	return max(0, min(0.99, deadZone))
+}
+
+func rumbleStrength(strength float32) uint16 {
+	return uint16(max(0, min(1, strength)) * math.MaxUint16)
+}
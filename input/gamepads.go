@@ -0,0 +1,142 @@
+package input
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Gamepads keeps slots of connected game controllers.
+// It is required to use one instance of Gamepads, which initialized by Engine (Engine.GetGamepads).
+//
+// Connected controller takes the first free slot, so controllers keep their indices
+// while other controllers are connected and disconnected.
+type Gamepads struct {
+	gamepads []*Gamepad
+
+	onConnected    func(g *Gamepad)
+	onDisconnected func(g *Gamepad)
+}
+
+// NewGamepads initialize new Gamepads object, should be called only once (done inside Engine)
+func NewGamepads() *Gamepads {
+	return &Gamepads{}
+}
+
+// Get returns gamepad of slot with index, creating disconnected gamepad if slot was never used,
+// so gamepad can be stored before controller is connected.
+func (gs *Gamepads) Get(index int) *Gamepad {
+	if index < 0 {
+		return nil
+	}
+	for len(gs.gamepads) <= index {
+		gs.gamepads = append(gs.gamepads, newGamepad(len(gs.gamepads)))
+	}
+	return gs.gamepads[index]
+}
+
+// GetConnected returns connected gamepads ordered by index.
+func (gs *Gamepads) GetConnected() []*Gamepad {
+	result := make([]*Gamepad, 0, len(gs.gamepads))
+	for _, g := range gs.gamepads {
+		if g.IsConnected() {
+			result = append(result, g)
+		}
+	}
+	return result
+}
+
+// Count returns number of connected gamepads.
+func (gs *Gamepads) Count() int {
+	return len(gs.GetConnected())
+}
+
+// SetOnConnected sets function called when controller is connected, with gamepad it was assigned to.
+// Controllers connected before engine start are reported too.
+func (gs *Gamepads) SetOnConnected(onConnected func(g *Gamepad)) {
+	gs.onConnected = onConnected
+}
+
+// SetOnDisconnected sets function called when controller of gamepad is disconnected.
+func (gs *Gamepads) SetOnDisconnected(onDisconnected func(g *Gamepad)) {
+	gs.onDisconnected = onDisconnected
+}
+
+// HandleDeviceEvent is an internal function, which connects and disconnects controllers.
+func (gs *Gamepads) HandleDeviceEvent(e *sdl.ControllerDeviceEvent) {
+	switch e.Type {
+	case sdl.CONTROLLERDEVICEADDED:
+		// Which is device index for added controllers
+		controller := sdl.GameControllerOpen(int(e.Which))
+		if controller == nil {
+			return
+		}
+		id := controller.Joystick().InstanceID()
+		if gs.findByInstance(id) != nil {
+			// already opened controller is reported again
+			controller.Close()
+			return
+		}
+
+		g := gs.Get(gs.freeIndex())
+		g.connect(controller)
+		if gs.onConnected != nil {
+			gs.onConnected(g)
+		}
+
+	case sdl.CONTROLLERDEVICEREMOVED:
+		g := gs.findByInstance(e.Which)
+		if g == nil {
+			return
+		}
+		g.disconnect()
+		if gs.onDisconnected != nil {
+			gs.onDisconnected(g)
+		}
+	}
+}
+
+// HandleButtonEvent is an internal function that used to keep track of gamepad buttons.
+func (gs *Gamepads) HandleButtonEvent(e *sdl.ControllerButtonEvent) {
+	if g := gs.findByInstance(e.Which); g != nil {
+		g.setButton(GamepadButton(e.Button), e.State == sdl.PRESSED)
+	}
+}
+
+// HandleAxisEvent is an internal function that used to keep track of gamepad axes.
+func (gs *Gamepads) HandleAxisEvent(e *sdl.ControllerAxisEvent) {
+	if g := gs.findByInstance(e.Which); g != nil && int(e.Axis) < gamepadAxisCount {
+		g.axes[e.Axis] = e.Value
+	}
+}
+
+// ApplyDeferred is an internal function.
+// Function used to clear button down/up events of the frame, does not affect button pressed.
+func (gs *Gamepads) ApplyDeferred() {
+	for _, g := range gs.gamepads {
+		g.clearFrameEvents()
+	}
+}
+
+// Close is an internal function, which closes all connected controllers.
+func (gs *Gamepads) Close() {
+	for _, g := range gs.gamepads {
+		g.disconnect()
+	}
+}
+
+func (gs *Gamepads) findByInstance(id sdl.JoystickID) *Gamepad {
+	for _, g := range gs.gamepads {
+		if g.IsConnected() && g.instanceID == id {
+			return g
+		}
+	}
+	return nil
+}
+
+func (gs *Gamepads) freeIndex() int {
+	for i, g := range gs.gamepads {
+		if !g.IsConnected() {
+			return i
+		}
+	}
+	return len(gs.gamepads)
+}
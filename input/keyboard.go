@@ -4,65 +4,125 @@ import "github.com/veandco/go-sdl2/sdl"
 
 // Keyboard represent keyboard controller.
 // It is required to use one instance of Keyboard, which initialized by Engine (Engine.GetKeyboard).
+//
+// State of keyboard is a snapshot made from all events handled before the frame, so it does not change
+// during the frame, and any number of callers get the same result.
 type Keyboard struct {
-	state []uint8
-
-	// 0 - no last event, 1 - last event mouse up, 2 - last event mouse down,
-	// 3 - previous last event was mouse up, 4 - previous last event was mouse down
-	buttonLastEvent map[Scancode]uint32
+	held [numScancodes]bool
+	// keys which changed state or were repeated during last frame, cleared in ApplyDeferred
+	pressed  [numScancodes]bool
+	released [numScancodes]bool
+	repeated [numScancodes]bool
 
-	deferredChanges map[Scancode]uint32
+	modifiers Keymod
 }
 
+const numScancodes = sdl.NUM_SCANCODES
+
 // NewKeyboard initialize new Keyboard object, should be called only once (done inside Engine)
 func NewKeyboard() *Keyboard {
-	return &Keyboard{
-		state:           sdl.GetKeyboardState(),
-		buttonLastEvent: make(map[Scancode]uint32),
-		deferredChanges: make(map[Scancode]uint32),
-	}
+	return &Keyboard{}
+}
+
+// Held returns true if provided key is held down.
+func (k *Keyboard) Held(btn Scancode) bool {
+	return validScancode(btn) && k.held[btn]
+}
+
+// JustPressed returns true if provided key was pressed during last frame, repeats of held key are not reported.
+func (k *Keyboard) JustPressed(btn Scancode) bool {
+	return validScancode(btn) && k.pressed[btn]
+}
+
+// JustReleased returns true if provided key was released during last frame.
+// Key pressed and released during the same frame is reported by both JustPressed and JustReleased.
+func (k *Keyboard) JustReleased(btn Scancode) bool {
+	return validScancode(btn) && k.released[btn]
+}
+
+// JustRepeated returns true if system key repeat was triggered for held key during last frame,
+// e.g. to move caret of text field while arrow key is held, together with JustPressed.
+func (k *Keyboard) JustRepeated(btn Scancode) bool {
+	return validScancode(btn) && k.repeated[btn]
+}
+
+// GetModifiers returns modifier keys held during last keyboard event, including Caps Lock and Num Lock.
+func (k *Keyboard) GetModifiers() Keymod {
+	return k.modifiers
+}
+
+// ShiftHeld returns true if any Shift key is held.
+func (k *Keyboard) ShiftHeld() bool {
+	return k.modifiers&KMOD_SHIFT != 0
+}
+
+// CtrlHeld returns true if any Ctrl key is held.
+func (k *Keyboard) CtrlHeld() bool {
+	return k.modifiers&KMOD_CTRL != 0
+}
+
+// AltHeld returns true if any Alt key is held.
+func (k *Keyboard) AltHeld() bool {
+	return k.modifiers&KMOD_ALT != 0
+}
+
+// GuiHeld returns true if any GUI key (windows, command, meta) is held.
+func (k *Keyboard) GuiHeld() bool {
+	return k.modifiers&KMOD_GUI != 0
 }
 
-// ButtonPressed returns true if provided button is pressed and false otherwise.
+// ButtonPressed returns true if provided button is pressed and false otherwise, same as Held.
 func (k *Keyboard) ButtonPressed(btn Scancode) bool {
-	return k.state[btn] == 1
+	return k.Held(btn)
 }
 
-// ButtonDown returns true if last event for provided button was key down.
+// ButtonDown returns true if provided button was pressed during last frame, same as JustPressed.
 func (k *Keyboard) ButtonDown(btn Scancode) bool {
-	if k.buttonLastEvent[btn] == 2 {
-		k.deferredChanges[btn] = 4
-		return true
-	}
-	return false
+	return k.JustPressed(btn)
 }
 
-// ButtonUp returns true if last event for provided button was key up.
+// ButtonUp returns true if provided button was released during last frame, same as JustReleased.
 func (k *Keyboard) ButtonUp(btn Scancode) bool {
-	if k.buttonLastEvent[btn] == 1 {
-		k.deferredChanges[btn] = 3
-		return true
-	}
-	return false
+	return k.JustReleased(btn)
 }
 
-// SetLastEvent is an internal function that used to keep track of last keyboard button event for each button
+// SetLastEvent is an internal function that used to keep track of keyboard events.
 func (k *Keyboard) SetLastEvent(e *sdl.KeyboardEvent) {
-	// TODO: add some timeout after one state will be cleared, in case some event waw ignored, lost, etc.
-	if e.Type == sdl.KEYUP && k.buttonLastEvent[e.Keysym.Scancode] != 3 {
-		k.buttonLastEvent[e.Keysym.Scancode] = 1
-	} else if e.Type == sdl.KEYDOWN && k.buttonLastEvent[e.Keysym.Scancode] != 4 {
-		k.buttonLastEvent[e.Keysym.Scancode] = 2
+	k.modifiers = Keymod(e.Keysym.Mod)
+
+	btn := e.Keysym.Scancode
+	if !validScancode(btn) {
+		return
+	}
+	switch e.Type {
+	case sdl.KEYDOWN:
+		if k.held[btn] {
+			if e.Repeat != 0 {
+				k.repeated[btn] = true
+			}
+			return
+		}
+		k.held[btn] = true
+		k.pressed[btn] = true
+	case sdl.KEYUP:
+		if !k.held[btn] {
+			return
+		}
+		k.held[btn] = false
+		k.released[btn] = true
 	}
 }
 
 // ApplyDeferred is an internal function.
-// Function used to set proper values for key down/up event, does not affect key pressed.
+// Function used to clear key down/up and repeat events of the frame, does not affect held keys.
 func (k *Keyboard) ApplyDeferred() {
-	for btn, val := range k.deferredChanges {
-		k.buttonLastEvent[btn] = val
-	}
-	k.deferredChanges = make(map[Scancode]uint32)
+	k.pressed = [numScancodes]bool{}
+	k.released = [numScancodes]bool{}
+	k.repeated = [numScancodes]bool{}
+}
+
+func validScancode(btn Scancode) bool {
+	return btn < numScancodes
 }
 
 // GetButtonName returns human-readable name for provided scancode
@@ -217,3 +277,25 @@ const (
 	SCANCODE_RALT   = sdl.SCANCODE_RALT   // "Right Alt" (alt gr, option)
 	SCANCODE_RGUI   = sdl.SCANCODE_RGUI   // "Right GUI" (windows, command (apple), meta)
 )
+
+// Keymod is an alias made for consistency, it is a mask of modifier keys, see sdl.KMOD_*.
+type Keymod = sdl.Keymod
+
+const (
+	KMOD_NONE   Keymod = sdl.KMOD_NONE   // no modifier is held
+	KMOD_LSHIFT Keymod = sdl.KMOD_LSHIFT // left Shift
+	KMOD_RSHIFT Keymod = sdl.KMOD_RSHIFT // right Shift
+	KMOD_LCTRL  Keymod = sdl.KMOD_LCTRL  // left Ctrl
+	KMOD_RCTRL  Keymod = sdl.KMOD_RCTRL  // right Ctrl
+	KMOD_LALT   Keymod = sdl.KMOD_LALT   // left Alt
+	KMOD_RALT   Keymod = sdl.KMOD_RALT   // right Alt
+	KMOD_LGUI   Keymod = sdl.KMOD_LGUI   // left GUI (windows, command (apple), meta)
+	KMOD_RGUI   Keymod = sdl.KMOD_RGUI   // right GUI (windows, command (apple), meta)
+	KMOD_NUM    Keymod = sdl.KMOD_NUM    // Num Lock is on
+	KMOD_CAPS   Keymod = sdl.KMOD_CAPS   // Caps Lock is on
+
+	KMOD_SHIFT Keymod = sdl.KMOD_SHIFT // any Shift
+	KMOD_CTRL  Keymod = sdl.KMOD_CTRL  // any Ctrl
+	KMOD_ALT   Keymod = sdl.KMOD_ALT   // any Alt
+	KMOD_GUI   Keymod = sdl.KMOD_GUI   // any GUI
+)
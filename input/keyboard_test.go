@@ -0,0 +1,156 @@
+package input
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+	"testing"
+)
+
+func keyEvent(eventType uint32, scancode Scancode, repeat bool, mod Keymod) *sdl.KeyboardEvent {
+	e := &sdl.KeyboardEvent{
+		Type:   eventType,
+		Keysym: sdl.Keysym{Scancode: scancode, Mod: uint16(mod)},
+	}
+	if eventType == sdl.KEYDOWN {
+		e.State = sdl.PRESSED
+	}
+	if repeat {
+		e.Repeat = 1
+	}
+	return e
+}
+
+func down(scancode Scancode) *sdl.KeyboardEvent {
+	return keyEvent(sdl.KEYDOWN, scancode, false, KMOD_NONE)
+}
+
+func up(scancode Scancode) *sdl.KeyboardEvent {
+	return keyEvent(sdl.KEYUP, scancode, false, KMOD_NONE)
+}
+
+func TestKeyboardEdges(t *testing.T) {
+	cases := []struct {
+		name string
+		// events handled before previous frame, and before checked frame
+		previous []*sdl.KeyboardEvent
+		events   []*sdl.KeyboardEvent
+
+		held, pressed, released, repeated bool
+	}{
+		{"no events", nil, nil, false, false, false, false},
+		{"pressed", nil, []*sdl.KeyboardEvent{down(SCANCODE_A)}, true, true, false, false},
+		{"held from previous frame", []*sdl.KeyboardEvent{down(SCANCODE_A)}, nil, true, false, false, false},
+		{"released", []*sdl.KeyboardEvent{down(SCANCODE_A)}, []*sdl.KeyboardEvent{up(SCANCODE_A)}, false, false, true, false},
+		{"pressed and released in one frame", nil, []*sdl.KeyboardEvent{down(SCANCODE_A), up(SCANCODE_A)}, false, true, true, false},
+		{"released and pressed again in one frame", []*sdl.KeyboardEvent{down(SCANCODE_A)},
+			[]*sdl.KeyboardEvent{up(SCANCODE_A), down(SCANCODE_A)}, true, true, true, false},
+		{"repeated", []*sdl.KeyboardEvent{down(SCANCODE_A)},
+			[]*sdl.KeyboardEvent{keyEvent(sdl.KEYDOWN, SCANCODE_A, true, KMOD_NONE)}, true, false, false, true},
+		{"repeated in frame of press", nil,
+			[]*sdl.KeyboardEvent{down(SCANCODE_A), keyEvent(sdl.KEYDOWN, SCANCODE_A, true, KMOD_NONE)}, true, true, false, true},
+		{"release without press", nil, []*sdl.KeyboardEvent{up(SCANCODE_A)}, false, false, false, false},
+		{"other key", nil, []*sdl.KeyboardEvent{down(SCANCODE_B)}, false, false, false, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			k := NewKeyboard()
+			for _, e := range c.previous {
+				k.SetLastEvent(e)
+			}
+			k.ApplyDeferred()
+			for _, e := range c.events {
+				k.SetLastEvent(e)
+			}
+
+			// any number of callers get the same state during the frame
+			for i := 0; i < 3; i++ {
+				if k.Held(SCANCODE_A) != c.held {
+					t.Errorf("call %d: Held is %v, expected %v", i, k.Held(SCANCODE_A), c.held)
+				}
+				if k.JustPressed(SCANCODE_A) != c.pressed {
+					t.Errorf("call %d: JustPressed is %v, expected %v", i, k.JustPressed(SCANCODE_A), c.pressed)
+				}
+				if k.JustReleased(SCANCODE_A) != c.released {
+					t.Errorf("call %d: JustReleased is %v, expected %v", i, k.JustReleased(SCANCODE_A), c.released)
+				}
+				if k.JustRepeated(SCANCODE_A) != c.repeated {
+					t.Errorf("call %d: JustRepeated is %v, expected %v", i, k.JustRepeated(SCANCODE_A), c.repeated)
+				}
+				if k.ButtonDown(SCANCODE_A) != c.pressed || k.ButtonUp(SCANCODE_A) != c.released {
+					t.Errorf("call %d: ButtonDown and ButtonUp differ from JustPressed and JustReleased", i)
+				}
+			}
+
+			// edges are cleared on the next frame, held keys stay held
+			k.ApplyDeferred()
+			if k.JustPressed(SCANCODE_A) || k.JustReleased(SCANCODE_A) || k.JustRepeated(SCANCODE_A) {
+				t.Errorf("edges are not cleared on the next frame")
+			}
+			if k.Held(SCANCODE_A) != c.held {
+				t.Errorf("Held on the next frame is %v, expected %v", k.Held(SCANCODE_A), c.held)
+			}
+		})
+	}
+}
+
+func TestKeyboardModifiers(t *testing.T) {
+	cases := []struct {
+		name string
+		mod  Keymod
+
+		shift, ctrl, alt, gui bool
+	}{
+		{"none", KMOD_NONE, false, false, false, false},
+		{"left shift", KMOD_LSHIFT, true, false, false, false},
+		{"right shift", KMOD_RSHIFT, true, false, false, false},
+		{"ctrl", KMOD_LCTRL, false, true, false, false},
+		{"alt", KMOD_RALT, false, false, true, false},
+		{"gui", KMOD_LGUI, false, false, false, true},
+		{"ctrl and shift", KMOD_RCTRL | KMOD_LSHIFT, true, true, false, false},
+		{"caps lock is not shift", KMOD_CAPS, false, false, false, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			k := NewKeyboard()
+			k.SetLastEvent(keyEvent(sdl.KEYDOWN, SCANCODE_S, false, c.mod))
+
+			for i := 0; i < 2; i++ {
+				if k.GetModifiers() != c.mod {
+					t.Errorf("GetModifiers is %v, expected %v", k.GetModifiers(), c.mod)
+				}
+				if k.ShiftHeld() != c.shift || k.CtrlHeld() != c.ctrl || k.AltHeld() != c.alt || k.GuiHeld() != c.gui {
+					t.Errorf("shift %v, ctrl %v, alt %v, gui %v, expected %v, %v, %v, %v",
+						k.ShiftHeld(), k.CtrlHeld(), k.AltHeld(), k.GuiHeld(), c.shift, c.ctrl, c.alt, c.gui)
+				}
+				// modifiers are state, not edges, so they are kept on the next frame
+				k.ApplyDeferred()
+			}
+		})
+	}
+}
+
+func TestKeyboardRepeatWithModifiers(t *testing.T) {
+	k := NewKeyboard()
+	k.SetLastEvent(keyEvent(sdl.KEYDOWN, SCANCODE_LCTRL, false, KMOD_LCTRL))
+	k.SetLastEvent(keyEvent(sdl.KEYDOWN, SCANCODE_LEFT, false, KMOD_LCTRL))
+	k.ApplyDeferred()
+
+	k.SetLastEvent(keyEvent(sdl.KEYDOWN, SCANCODE_LEFT, true, KMOD_LCTRL))
+	if !k.JustRepeated(SCANCODE_LEFT) || k.JustPressed(SCANCODE_LEFT) || !k.CtrlHeld() {
+		t.Errorf("repeat of Ctrl+Left is not reported")
+	}
+
+	k.SetLastEvent(keyEvent(sdl.KEYUP, SCANCODE_LCTRL, false, KMOD_NONE))
+	if k.CtrlHeld() || !k.JustReleased(SCANCODE_LCTRL) {
+		t.Errorf("release of Ctrl is not reported")
+	}
+}
+
+func TestKeyboardInvalidScancode(t *testing.T) {
+	k := NewKeyboard()
+	k.SetLastEvent(down(numScancodes + 1))
+	if k.Held(numScancodes+1) || k.JustPressed(numScancodes+1) {
+		t.Errorf("invalid scancode is reported")
+	}
+}
@@ -1,26 +1,33 @@
 package input
 
 import (
+	"fmt"
 	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/resource"
 	"github.com/veandco/go-sdl2/sdl"
 )
 
 // Mouse represent mouse controller.
 // It is required to use one instance of Mouse, which initialized by Engine (Engine.GetMouse).
+//
+// State of buttons is a snapshot made from all events handled before the frame, the same way as in Keyboard.
 type Mouse struct {
-	// 0 - no last event, 1 - last event mouse up, 2 - last event mouse down
-	// uint32 used instead of MouseButtonType because sdl mouse event provides some index which starts from 1 for some reason
-	buttonLastEvent map[uint32]uint32
+	held [mouseButtonCount]bool
 
-	deferredChanges map[uint32]uint32
+	// events of the current frame, cleared in ApplyDeferred
+	pressed  [mouseButtonCount]bool
+	released [mouseButtonCount]bool
+	wheel    basic.Point
+	motion   basic.Point
+	// number of clicks of last button down event of the frame
+	clicks [mouseButtonCount]int
+
+	customCursor *sdl.Cursor
 }
 
 // NewMouse initialize new Mouse object, should be called only once (done inside Engine)
 func NewMouse() *Mouse {
-	return &Mouse{
-		buttonLastEvent: make(map[uint32]uint32),
-		deferredChanges: make(map[uint32]uint32),
-	}
+	return &Mouse{}
 }
 
 // GetPosition return position of mouse relative to window space,
@@ -40,48 +47,190 @@ const (
 	MouseButtonLeft   MouseButtonType = iota
 	MouseButtonMiddle MouseButtonType = iota
 	MouseButtonRight  MouseButtonType = iota
+	MouseButtonX1     MouseButtonType = iota // first extra button, usually "back"
+	MouseButtonX2     MouseButtonType = iota // second extra button, usually "forward"
+
+	mouseButtonCount = iota
 )
 
-// ButtonPressed returns true if provided button is pressed and false otherwise.
+// Held returns true if provided button is held down.
+func (m *Mouse) Held(btn MouseButtonType) bool {
+	return btn < mouseButtonCount && m.held[btn]
+}
+
+// JustPressed returns true if provided button was pressed during last frame.
+func (m *Mouse) JustPressed(btn MouseButtonType) bool {
+	return btn < mouseButtonCount && m.pressed[btn]
+}
+
+// JustReleased returns true if provided button was released during last frame.
+// Button pressed and released during the same frame is reported by both JustPressed and JustReleased.
+func (m *Mouse) JustReleased(btn MouseButtonType) bool {
+	return btn < mouseButtonCount && m.released[btn]
+}
+
+// ButtonPressed returns true if provided button is pressed and false otherwise, same as Held.
 func (m *Mouse) ButtonPressed(btn MouseButtonType) bool {
-	_, _, state := sdl.GetMouseState()
-	return (state>>uint32(btn))&1 == 1
+	return m.Held(btn)
 }
 
-// ButtonDown returns true if last event for provided button was mouse button down.
+// ButtonDown returns true if provided button was pressed during last frame, same as JustPressed.
 func (m *Mouse) ButtonDown(btn MouseButtonType) bool {
-	btnInd := uint32(btn) + 1
-	if m.buttonLastEvent[btnInd] == 2 {
-		m.deferredChanges[btnInd] = 0
-		return true
-	}
-	return false
+	return m.JustPressed(btn)
 }
 
-// ButtonUp returns true if last event for provided button was mouse button up.
+// ButtonUp returns true if provided button was released during last frame, same as JustReleased.
 func (m *Mouse) ButtonUp(btn MouseButtonType) bool {
-	btnInd := uint32(btn) + 1
-	if m.buttonLastEvent[btnInd] == 1 {
-		m.deferredChanges[btnInd] = 0
-		return true
-	}
-	return false
+	return m.JustReleased(btn)
 }
 
 // ApplyDeferred is an internal function.
-// Function used to set proper values for button down/up event, does not affect button pressed.
+// Function used to clear button down/up events, wheel, motion and clicks of the frame, does not affect held buttons.
 func (m *Mouse) ApplyDeferred() {
-	for btn, val := range m.deferredChanges {
-		m.buttonLastEvent[btn] = val
-	}
-	m.deferredChanges = make(map[uint32]uint32)
+	m.pressed = [mouseButtonCount]bool{}
+	m.released = [mouseButtonCount]bool{}
+	m.wheel = basic.Point{}
+	m.motion = basic.Point{}
+	m.clicks = [mouseButtonCount]int{}
 }
 
-// SetLastEvent is an internal function that used to keep track of last mouse button event
+// SetLastEvent is an internal function that used to keep track of mouse button events
 func (m *Mouse) SetLastEvent(e *sdl.MouseButtonEvent) {
-	if e.Type == sdl.MOUSEBUTTONUP {
-		m.buttonLastEvent[uint32(e.Button)] = 1
-	} else if e.Type == sdl.MOUSEBUTTONDOWN {
-		m.buttonLastEvent[uint32(e.Button)] = 2
+	// sdl mouse event provides button index which starts from 1
+	if e.Button == 0 || MouseButtonType(e.Button-1) >= mouseButtonCount {
+		return
+	}
+	btn := MouseButtonType(e.Button - 1)
+
+	switch e.Type {
+	case sdl.MOUSEBUTTONDOWN:
+		m.clicks[btn] = int(e.Clicks)
+		if !m.held[btn] {
+			m.held[btn] = true
+			m.pressed[btn] = true
+		}
+	case sdl.MOUSEBUTTONUP:
+		if m.held[btn] {
+			m.held[btn] = false
+			m.released[btn] = true
+		}
+	}
+}
+
+// HandleWheelEvent is an internal function that used to sum wheel scrolling of the frame.
+func (m *Mouse) HandleWheelEvent(e *sdl.MouseWheelEvent) {
+	x, y := e.PreciseX, e.PreciseY
+	if x == 0 && y == 0 {
+		// precise values are not provided by SDL before 2.0.18
+		x, y = float32(e.X), float32(e.Y)
+	}
+	if e.Direction == sdl.MOUSEWHEEL_FLIPPED {
+		x, y = -x, -y
+	}
+	m.wheel.X += x
+	m.wheel.Y += y
+}
+
+// HandleMotionEvent is an internal function that used to sum mouse motion of the frame.
+func (m *Mouse) HandleMotionEvent(e *sdl.MouseMotionEvent) {
+	m.motion.X += float32(e.XRel)
+	m.motion.Y += float32(e.YRel)
+}
+
+// GetWheel returns scrolling of mouse wheel during last frame, positive Y is scrolling up (away from user),
+// positive X is scrolling right.
+func (m *Mouse) GetWheel() basic.Point {
+	return m.wheel
+}
+
+// GetMotion returns movement of mouse during last frame in pixels, it is reported in relative mode too,
+// when position of cursor does not change.
+func (m *Mouse) GetMotion() basic.Point {
+	return m.motion
+}
+
+// GetClickCount returns number of quick successive clicks of provided button, if it was pressed during last frame
+// (1 for single click, 2 for double click, etc.), 0 if button was not pressed.
+func (m *Mouse) GetClickCount(btn MouseButtonType) int {
+	if btn >= mouseButtonCount {
+		return 0
+	}
+	return m.clicks[btn]
+}
+
+// DoubleClicked returns true if provided button was pressed second time in a row during last frame.
+func (m *Mouse) DoubleClicked(btn MouseButtonType) bool {
+	return m.GetClickCount(btn) == 2
+}
+
+// TripleClicked returns true if provided button was pressed third time in a row during last frame.
+func (m *Mouse) TripleClicked(btn MouseButtonType) bool {
+	return m.GetClickCount(btn) == 3
+}
+
+// SetRelativeMode enables or disables relative mode, in which cursor is hidden and captured by window,
+// and only motion (GetMotion) is reported, e.g. for first-person camera.
+func (m *Mouse) SetRelativeMode(enabled bool) error {
+	if sdl.SetRelativeMouseMode(enabled) != 0 {
+		return fmt.Errorf("cannot set relative mouse mode: %v", sdl.GetError())
+	}
+	return nil
+}
+
+// IsRelativeMode returns true if relative mode is enabled.
+func (m *Mouse) IsRelativeMode() bool {
+	return sdl.GetRelativeMouseMode()
+}
+
+// SetCursorVisible shows or hides cursor when it is over the window.
+func (m *Mouse) SetCursorVisible(visible bool) error {
+	toggle := sdl.DISABLE
+	if visible {
+		toggle = sdl.ENABLE
+	}
+	if _, err := sdl.ShowCursor(toggle); err != nil {
+		return fmt.Errorf("cannot change cursor visibility: %w", err)
+	}
+	return nil
+}
+
+// IsCursorVisible returns true if cursor is shown.
+func (m *Mouse) IsCursorVisible() bool {
+	state, err := sdl.ShowCursor(sdl.QUERY)
+	return err == nil && state == sdl.ENABLE
+}
+
+// SetCursor sets image as cursor, hotSpot is a point of the image (in pixels from its top left corner),
+// which is the position of the mouse, e.g. tip of an arrow.
+func (m *Mouse) SetCursor(image *resource.Image, hotSpot basic.Point) error {
+	surf := image.GetSurface()
+	if surf == nil {
+		return fmt.Errorf("cannot set cursor, image (%s) is not loaded", image.GetPath())
+	}
+
+	cursor := sdl.CreateColorCursor(surf, int32(hotSpot.X), int32(hotSpot.Y))
+	if cursor == nil {
+		return fmt.Errorf("cannot create cursor from image (%s): %v", image.GetPath(), sdl.GetError())
+	}
+
+	sdl.SetCursor(cursor)
+	m.freeCustomCursor()
+	m.customCursor = cursor
+	return nil
+}
+
+// ResetCursor sets default cursor of the system.
+func (m *Mouse) ResetCursor() {
+	if m.customCursor == nil {
+		return
+	}
+	sdl.SetCursor(sdl.GetDefaultCursor())
+	m.freeCustomCursor()
+}
+
+func (m *Mouse) freeCustomCursor() {
+	if m.customCursor != nil {
+		sdl.FreeCursor(m.customCursor)
+		m.customCursor = nil
 	}
 }
@@ -0,0 +1,129 @@
+package input
+
+import (
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/veandco/go-sdl2/sdl"
+	"testing"
+)
+
+func mouseEvent(eventType uint32, btn MouseButtonType, clicks uint8) *sdl.MouseButtonEvent {
+	e := &sdl.MouseButtonEvent{
+		Type:   eventType,
+		Button: uint8(btn) + 1,
+		Clicks: clicks,
+	}
+	if eventType == sdl.MOUSEBUTTONDOWN {
+		e.State = sdl.PRESSED
+	}
+	return e
+}
+
+func mouseDown(btn MouseButtonType) *sdl.MouseButtonEvent {
+	return mouseEvent(sdl.MOUSEBUTTONDOWN, btn, 1)
+}
+
+func mouseUp(btn MouseButtonType) *sdl.MouseButtonEvent {
+	return mouseEvent(sdl.MOUSEBUTTONUP, btn, 1)
+}
+
+func TestMouseEdges(t *testing.T) {
+	cases := []struct {
+		name     string
+		btn      MouseButtonType
+		previous []*sdl.MouseButtonEvent
+		events   []*sdl.MouseButtonEvent
+
+		held, pressed, released bool
+		clicks                  int
+	}{
+		{"no events", MouseButtonLeft, nil, nil, false, false, false, 0},
+		{"pressed", MouseButtonLeft, nil, []*sdl.MouseButtonEvent{mouseDown(MouseButtonLeft)}, true, true, false, 1},
+		{"held from previous frame", MouseButtonLeft, []*sdl.MouseButtonEvent{mouseDown(MouseButtonLeft)}, nil, true, false, false, 0},
+		{"released", MouseButtonRight, []*sdl.MouseButtonEvent{mouseDown(MouseButtonRight)},
+			[]*sdl.MouseButtonEvent{mouseUp(MouseButtonRight)}, false, false, true, 0},
+		{"clicked in one frame", MouseButtonMiddle, nil,
+			[]*sdl.MouseButtonEvent{mouseDown(MouseButtonMiddle), mouseUp(MouseButtonMiddle)}, false, true, true, 1},
+		{"double click", MouseButtonLeft, []*sdl.MouseButtonEvent{mouseDown(MouseButtonLeft), mouseUp(MouseButtonLeft)},
+			[]*sdl.MouseButtonEvent{mouseEvent(sdl.MOUSEBUTTONDOWN, MouseButtonLeft, 2)}, true, true, false, 2},
+		{"extra button", MouseButtonX2, nil, []*sdl.MouseButtonEvent{mouseDown(MouseButtonX2)}, true, true, false, 1},
+		{"other button", MouseButtonLeft, nil, []*sdl.MouseButtonEvent{mouseDown(MouseButtonX1)}, false, false, false, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := NewMouse()
+			for _, e := range c.previous {
+				m.SetLastEvent(e)
+			}
+			m.ApplyDeferred()
+			for _, e := range c.events {
+				m.SetLastEvent(e)
+			}
+
+			// any number of callers get the same state during the frame
+			for i := 0; i < 3; i++ {
+				if m.Held(c.btn) != c.held {
+					t.Errorf("call %d: Held is %v, expected %v", i, m.Held(c.btn), c.held)
+				}
+				if m.JustPressed(c.btn) != c.pressed {
+					t.Errorf("call %d: JustPressed is %v, expected %v", i, m.JustPressed(c.btn), c.pressed)
+				}
+				if m.JustReleased(c.btn) != c.released {
+					t.Errorf("call %d: JustReleased is %v, expected %v", i, m.JustReleased(c.btn), c.released)
+				}
+				if m.GetClickCount(c.btn) != c.clicks {
+					t.Errorf("call %d: GetClickCount is %d, expected %d", i, m.GetClickCount(c.btn), c.clicks)
+				}
+				if m.DoubleClicked(c.btn) != (c.clicks == 2) {
+					t.Errorf("call %d: DoubleClicked is %v", i, m.DoubleClicked(c.btn))
+				}
+			}
+
+			// edges are cleared on the next frame, held buttons stay held
+			m.ApplyDeferred()
+			if m.JustPressed(c.btn) || m.JustReleased(c.btn) || m.GetClickCount(c.btn) != 0 {
+				t.Errorf("edges are not cleared on the next frame")
+			}
+			if m.Held(c.btn) != c.held {
+				t.Errorf("Held on the next frame is %v, expected %v", m.Held(c.btn), c.held)
+			}
+		})
+	}
+}
+
+func TestMouseWheelAndMotion(t *testing.T) {
+	m := NewMouse()
+	m.HandleWheelEvent(&sdl.MouseWheelEvent{Type: sdl.MOUSEWHEEL, X: 0, Y: 1, PreciseY: 1})
+	m.HandleWheelEvent(&sdl.MouseWheelEvent{Type: sdl.MOUSEWHEEL, X: 0, Y: 2})
+	m.HandleWheelEvent(&sdl.MouseWheelEvent{Type: sdl.MOUSEWHEEL, X: 1, Y: 0, PreciseX: 1, Direction: sdl.MOUSEWHEEL_FLIPPED})
+	m.HandleMotionEvent(&sdl.MouseMotionEvent{Type: sdl.MOUSEMOTION, XRel: 3, YRel: -2})
+	m.HandleMotionEvent(&sdl.MouseMotionEvent{Type: sdl.MOUSEMOTION, XRel: 1, YRel: -1})
+
+	for i := 0; i < 2; i++ {
+		if wheel := m.GetWheel(); wheel != (basic.Point{X: -1, Y: 3}) {
+			t.Errorf("GetWheel is %v, expected {-1 3}", wheel)
+		}
+		if motion := m.GetMotion(); motion != (basic.Point{X: 4, Y: -3}) {
+			t.Errorf("GetMotion is %v, expected {4 -3}", motion)
+		}
+	}
+
+	m.ApplyDeferred()
+	if m.GetWheel() != (basic.Point{}) || m.GetMotion() != (basic.Point{}) {
+		t.Errorf("wheel and motion are not cleared on the next frame")
+	}
+}
+
+func TestMouseInvalidButton(t *testing.T) {
+	m := NewMouse()
+	m.SetLastEvent(&sdl.MouseButtonEvent{Type: sdl.MOUSEBUTTONDOWN, Button: 0})
+	m.SetLastEvent(&sdl.MouseButtonEvent{Type: sdl.MOUSEBUTTONDOWN, Button: 100})
+	for btn := MouseButtonType(0); btn < mouseButtonCount; btn++ {
+		if m.Held(btn) {
+			t.Errorf("button %d is held after invalid events", btn)
+		}
+	}
+	if m.Held(100) || m.GetClickCount(100) != 0 {
+		t.Errorf("invalid button is reported")
+	}
+}
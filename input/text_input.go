@@ -0,0 +1,122 @@
+package input
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// TextInput receives text typed by user, e.g. for name entry and chat fields, including text composed
+// with input method editor (IME) for languages, which characters are typed with several keys.
+// It is required to use one instance of TextInput, which initialized by Engine (Engine.GetTextInput).
+//
+// Text input is disabled by default, because on some systems it shows on-screen keyboard or IME window,
+// it has to be started with Start while text field is focused.
+type TextInput struct {
+	// text typed during the current frame, cleared in ApplyDeferred
+	text string
+
+	composition       string
+	compositionCursor int
+	compositionLength int
+}
+
+// NewTextInput initialize new TextInput object, should be called only once (done inside Engine)
+func NewTextInput() *TextInput {
+	sdl.StopTextInput()
+	return &TextInput{}
+}
+
+// Start enables text input. Rect is an area of text field in window coordinates,
+// used by system to place IME candidates window next to it.
+func (t *TextInput) Start(rect basic.Rect) {
+	t.SetRect(rect)
+	sdl.StartTextInput()
+}
+
+// Stop disables text input and discards current composition.
+func (t *TextInput) Stop() {
+	sdl.StopTextInput()
+	t.clearComposition()
+}
+
+// IsActive returns true if text input is started.
+func (t *TextInput) IsActive() bool {
+	return sdl.IsTextInputActive()
+}
+
+// SetRect changes area of text field, e.g. when caret is moved.
+func (t *TextInput) SetRect(rect basic.Rect) {
+	sdl.SetTextInputRect(&sdl.Rect{X: int32(rect.X), Y: int32(rect.Y), W: int32(rect.Width), H: int32(rect.Height)})
+}
+
+// GetText returns UTF-8 text typed during last frame, empty string if nothing was typed.
+// Text composed with IME is returned when composition is committed.
+func (t *TextInput) GetText() string {
+	return t.text
+}
+
+// IsComposing returns true if user is composing text with IME, while composing
+// keys pressed by user (e.g. Backspace or Return) are handled by IME and should not be handled by text field.
+func (t *TextInput) IsComposing() bool {
+	return t.composition != ""
+}
+
+// GetComposition returns text which is being composed with IME and is not committed yet,
+// it should be shown in text field at the caret position, usually underlined.
+func (t *TextInput) GetComposition() string {
+	return t.composition
+}
+
+// GetCompositionCursor returns position of cursor in composition in characters (runes),
+// and length of selected part of composition starting from the cursor.
+func (t *TextInput) GetCompositionCursor() (cursor int, length int) {
+	return t.compositionCursor, t.compositionLength
+}
+
+// HandleTextInputEvent is an internal function that used to collect typed text.
+func (t *TextInput) HandleTextInputEvent(e *sdl.TextInputEvent) {
+	t.text += e.GetText()
+	t.clearComposition()
+}
+
+// HandleTextEditingEvent is an internal function that used to keep track of IME composition.
+func (t *TextInput) HandleTextEditingEvent(e *sdl.TextEditingEvent) {
+	t.composition = e.GetText()
+	t.compositionCursor = int(e.Start)
+	t.compositionLength = int(e.Length)
+}
+
+// ApplyDeferred is an internal function.
+// Function used to clear text typed during the frame, does not affect composition.
+func (t *TextInput) ApplyDeferred() {
+	t.text = ""
+}
+
+func (t *TextInput) clearComposition() {
+	t.composition = ""
+	t.compositionCursor = 0
+	t.compositionLength = 0
+}
+
+// GetClipboardText returns text from system clipboard.
+func GetClipboardText() (string, error) {
+	text, err := sdl.GetClipboardText()
+	if err != nil {
+		return "", fmt.Errorf("cannot get clipboard text: %w", err)
+	}
+	return text, nil
+}
+
+// SetClipboardText puts text into system clipboard.
+func SetClipboardText(text string) error {
+	if err := sdl.SetClipboardText(text); err != nil {
+		return fmt.Errorf("cannot set clipboard text: %w", err)
+	}
+	return nil
+}
+
+// HasClipboardText returns true if system clipboard contains text.
+func HasClipboardText() bool {
+	return sdl.HasClipboardText()
+}
@@ -0,0 +1,84 @@
+package prefab
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/core"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+)
+
+// Override changes instance of prefab after it was created.
+type Override func(node *core.Node) error
+
+// WithPosition sets position of the instance.
+func WithPosition(position basic.Point) Override {
+	return func(node *core.Node) error {
+		node.SetPosition(position)
+		return nil
+	}
+}
+
+// WithName sets name of the instance.
+func WithName(name string) Override {
+	return func(node *core.Node) error {
+		node.SetName(name)
+		return nil
+	}
+}
+
+// WithText sets text of the instance, which has to be text node.
+func WithText(text string) Override {
+	return func(node *core.Node) error {
+		info := node.GetTextInfo()
+		if info == nil {
+			return fmt.Errorf("cannot set text of node without text info (id=%d)", node.GetID())
+		}
+		info.Text = text
+		return nil
+	}
+}
+
+// WithColor sets color of text or primitive of the instance (see core.Node.SetColor).
+func WithColor(color primitive.Color) Override {
+	return func(node *core.Node) error {
+		return node.SetColor(color)
+	}
+}
+
+// WithLayer sets layer of the instance.
+func WithLayer(layer core.LayerType) Override {
+	return func(node *core.Node) error {
+		node.SetLayer(layer)
+		return nil
+	}
+}
+
+// Child applies overrides to the first descendant of the instance with name, searching children before grandchildren.
+func Child(name string, overrides ...Override) Override {
+	return func(node *core.Node) error {
+		child := findByName(node, name)
+		if child == nil {
+			return fmt.Errorf("prefab instance (id=%d) has no child with name %q", node.GetID(), name)
+		}
+		for _, override := range overrides {
+			if err := override(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// findByName searches descendants of node breadth-first.
+func findByName(node *core.Node, name string) *core.Node {
+	queue := node.GetChildren()
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if n.GetName() == name {
+			return n
+		}
+		queue = append(queue, n.GetChildren()...)
+	}
+	return nil
+}
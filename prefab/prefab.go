@@ -0,0 +1,80 @@
+// Package prefab provides reusable templates of node trees, which are instantiated many times with per-instance overrides.
+package prefab
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/core"
+	"github.com/SemyonHoyrish/GoPlayEngine/serialization"
+	"os"
+)
+
+// Prefab is a template of node tree. Every instance is a separate tree of nodes with new IDs.
+// Prefab have to be created with New, FromNode or Load.
+type Prefab struct {
+	build func() *core.Node
+}
+
+// New creates prefab from builder function, which is called for every instance and has to return new node tree.
+func New(build func() *core.Node) *Prefab {
+	return &Prefab{build: build}
+}
+
+// FromNode creates prefab from template node, instances are clones of the node (see core.Node.Clone).
+// Further changes of the template node affect new instances.
+func FromNode(template *core.Node) *Prefab {
+	return &Prefab{build: template.Clone}
+}
+
+// Load creates prefab from JSON file with node tree (see serialization.NodeFile).
+// Images and fonts are loaded once and shared by all instances.
+func Load(path string) (*Prefab, error) {
+	return LoadWith(serialization.NewLoader(), path)
+}
+
+// LoadWith creates prefab from JSON file with node tree, using loader to share images and fonts with other data.
+func LoadWith(loader *serialization.Loader, path string) (*Prefab, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read prefab (%s): %w", path, err)
+	}
+	template, err := loader.UnmarshalNode(content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load prefab (%s): %w", path, err)
+	}
+	return FromNode(template), nil
+}
+
+// Instantiate creates new instance of the prefab and applies overrides to it in order.
+func (p *Prefab) Instantiate(overrides ...Override) (*core.Node, error) {
+	node := p.build()
+	if node == nil {
+		return nil, fmt.Errorf("prefab builder returned nil node")
+	}
+
+	for _, override := range overrides {
+		if err := override(node); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// InstantiateInto creates new instance of the prefab, applies overrides and adds it to the scene.
+func (p *Prefab) InstantiateInto(scene *core.Scene, overrides ...Override) (*core.Node, error) {
+	node, err := p.Instantiate(overrides...)
+	if err != nil {
+		return nil, err
+	}
+	scene.AddNode(node)
+	return node, nil
+}
+
+// InstantiateChild creates new instance of the prefab, applies overrides and adds it as a child of parent node.
+func (p *Prefab) InstantiateChild(parent *core.Node, overrides ...Override) (*core.Node, error) {
+	node, err := p.Instantiate(overrides...)
+	if err != nil {
+		return nil, err
+	}
+	parent.AddChild(node)
+	return node, nil
+}
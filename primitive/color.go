@@ -1,6 +1,9 @@
 package primitive
 
-import "github.com/veandco/go-sdl2/sdl"
-
-// Color is a type alias to use inside game engine
-type Color = sdl.Color
+// Color is a RGBA color used inside game engine, alpha is not premultiplied.
+type Color struct {
+	R uint8
+	G uint8
+	B uint8
+	A uint8
+}
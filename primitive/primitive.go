@@ -16,3 +16,19 @@ type PrimitiveInterface interface {
 	GetPrimitiveType() PrimitiveType
 	GetColor() Color
 }
+
+// WithColor returns copy of primitive with changed color, primitive is returned unchanged if its type is unknown.
+func WithColor(p PrimitiveInterface, color Color) PrimitiveInterface {
+	switch p := p.(type) {
+	case Rectangle:
+		p.Color = color
+		return p
+	case Circle:
+		p.Color = color
+		return p
+	case Line:
+		p.Color = color
+		return p
+	}
+	return p
+}
@@ -0,0 +1,85 @@
+package render
+
+const (
+	// CacheLifetime is a number of frames entry is kept in Cache without being used.
+	CacheLifetime = 120
+	// cacheSweepInterval is a number of frames between searches of unused entries.
+	cacheSweepInterval = 60
+)
+
+// TextKey identifies rasterized text. Font is a font already loaded with specific size,
+// so reloading font, changing its size or text of the node results in a new key.
+// Color is not a part of the key, text is rasterized in white and colored when drawn,
+// so changing color every frame (e.g. with tween) does not rasterize text again.
+type TextKey struct {
+	Font Font
+	Text string
+}
+
+type cacheEntry[V any] struct {
+	value    V
+	lastUsed uint64
+}
+
+// Cache is used by backends to keep values created for rendering (textures, rasterized text) between frames,
+// values not used for CacheLifetime frames are released. Have to be initialized with NewCache.
+type Cache[K comparable, V any] struct {
+	entries map[K]*cacheEntry[V]
+	release func(V)
+	frame   uint64
+}
+
+// NewCache creates Cache, release is called for values removed from cache, it can be nil.
+func NewCache[K comparable, V any](release func(V)) *Cache[K, V] {
+	return &Cache[K, V]{
+		entries: make(map[K]*cacheEntry[V]),
+		release: release,
+	}
+}
+
+// Get returns value stored by key and marks it as used in current frame.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	entry, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	entry.lastUsed = c.frame
+	return entry.value, true
+}
+
+// Put stores value by key, releasing value previously stored by the same key.
+func (c *Cache[K, V]) Put(key K, value V) {
+	if entry, ok := c.entries[key]; ok && c.release != nil {
+		c.release(entry.value)
+	}
+	c.entries[key] = &cacheEntry[V]{value: value, lastUsed: c.frame}
+}
+
+// NextFrame have to be called once per frame, it periodically releases values which were not used recently.
+func (c *Cache[K, V]) NextFrame() {
+	c.frame++
+	if c.frame%cacheSweepInterval != 0 {
+		return
+	}
+
+	for key, entry := range c.entries {
+		if c.frame-entry.lastUsed > CacheLifetime {
+			if c.release != nil {
+				c.release(entry.value)
+			}
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Clear releases all values.
+func (c *Cache[K, V]) Clear() {
+	for key, entry := range c.entries {
+		if c.release != nil {
+			c.release(entry.value)
+		}
+		delete(c.entries, key)
+	}
+}
@@ -0,0 +1,37 @@
+package render
+
+import (
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"image"
+)
+
+// Image is a decoded image drawn by Renderer, have to be initialized with NewImage.
+// Images are compared by pointer, so backends cache data created from image (e.g. textures) while it is drawn.
+type Image struct {
+	pixels *image.NRGBA
+}
+
+// NewImage creates Image from pixels, pixels should not be changed after image is drawn.
+func NewImage(pixels *image.NRGBA) *Image {
+	return &Image{pixels: pixels}
+}
+
+// GetPixels returns pixels of the image.
+func (i *Image) GetPixels() *image.NRGBA {
+	return i.pixels
+}
+
+// GetSize returns size of the image in pixels.
+func (i *Image) GetSize() basic.Size {
+	return basic.Size{Width: float32(i.pixels.Bounds().Dx()), Height: float32(i.pixels.Bounds().Dy())}
+}
+
+// Font rasterizes text drawn by Renderer, it is a font loaded with specific size (see resource.Font.GetFace).
+// Fonts are compared with ==, so backends cache rasterized text per font.
+type Font interface {
+	// RenderText returns image of white text on transparent background, backends multiply it by color of the text,
+	// so text of any color is rasterized once.
+	RenderText(text string) (*Image, error)
+	// MeasureText returns size of image returned by RenderText for text.
+	MeasureText(text string) (basic.Size, error)
+}
@@ -0,0 +1,60 @@
+// Package render contains backends used by Engine to draw frames.
+//
+// Engine draws scene only through the Renderer interface, so the same scene can be drawn by SDLRenderer
+// into a window, or by software.Renderer into an image in memory.
+// Renderer does not depend on SDL types, SDLRenderer is the only part of the package which uses SDL,
+// so the package and software backend are built without cgo.
+// Packages core and resource are built without cgo too (images and fonts are decoded by Go code then),
+// so node trees can be drawn by software.Renderer with core.DrawScene or core.DrawNodes, e.g. in tests.
+package render
+
+import (
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"image"
+)
+
+// Renderer describes backend, which draws primitives, images and text into render target.
+// All coordinates are in pixels of render target.
+type Renderer interface {
+	// Clear fills whole render target with color, ignoring clip rectangle.
+	Clear(color primitive.Color) error
+	// FillRect draws filled rectangle.
+	FillRect(rect basic.Rect, color primitive.Color) error
+	// DrawLine draws line between two points.
+	DrawLine(from basic.Point, to basic.Point, color primitive.Color) error
+	// FillCircle draws filled circle.
+	FillCircle(center basic.Point, radius float32, color primitive.Color) error
+	// FillPolygon draws filled convex polygon.
+	FillPolygon(points []basic.Point, color primitive.Color) error
+	// DrawTexture draws src part of the image stretched to dst and transformed, whole image is used when src is nil.
+	DrawTexture(img *Image, src *basic.Rect, dst basic.Rect, transform TextureTransform) error
+	// DrawText draws text rasterized by font stretched to dst and transformed.
+	DrawText(text string, font Font, color primitive.Color, dst basic.Rect, transform TextureTransform) error
+	// SetClip limits drawing to rect, nil disables clipping.
+	SetClip(rect *basic.Rect) error
+	// Present shows everything drawn since previous Present.
+	Present() error
+
+	// ReadPixels returns copy of the current content of render target.
+	ReadPixels() (*image.RGBA, error)
+	// GetOutputSize returns size of render target.
+	GetOutputSize() basic.Size
+
+	// Destroy releases resources owned by renderer.
+	Destroy()
+}
+
+// TextureTransform describes rotation, mirroring and transparency of texture drawn into destination rectangle.
+type TextureTransform struct {
+	// Angle of rotation in degrees clockwise.
+	Angle float32
+	// Center of rotation relative to top left corner of destination rectangle.
+	Center basic.Point
+
+	FlipHorizontal bool
+	FlipVertical   bool
+
+	// Transparency of the texture, from 0 (drawn as is) to 1 (invisible).
+	Transparency float32
+}
@@ -0,0 +1,263 @@
+//go:build cgo
+
+package render
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"github.com/veandco/go-sdl2/gfx"
+	"github.com/veandco/go-sdl2/sdl"
+	"image"
+	"unsafe"
+)
+
+// SDLRenderer implements Renderer with sdl.Renderer, it is a default backend of Engine.
+// Textures of images and rasterized text are cached between frames, and released when they are not used for a while.
+// It is built only with cgo.
+type SDLRenderer struct {
+	renderer *sdl.Renderer
+
+	textures *Cache[*Image, *sdl.Texture]
+	texts    *Cache[TextKey, *sdl.Texture]
+}
+
+// NewSDLRenderer creates Renderer drawing with r, r is destroyed together with SDLRenderer.
+func NewSDLRenderer(r *sdl.Renderer) *SDLRenderer {
+	return &SDLRenderer{
+		renderer: r,
+		textures: NewCache[*Image](destroyTexture),
+		texts:    NewCache[TextKey](destroyTexture),
+	}
+}
+
+// GetSDLRenderer returns underlying sdl.Renderer.
+func (r *SDLRenderer) GetSDLRenderer() *sdl.Renderer {
+	return r.renderer
+}
+
+func (r *SDLRenderer) Clear(color primitive.Color) error {
+	if err := r.renderer.SetDrawColor(color.R, color.G, color.B, color.A); err != nil {
+		return err
+	}
+	return r.renderer.Clear()
+}
+
+func (r *SDLRenderer) FillRect(rect basic.Rect, color primitive.Color) error {
+	if err := r.setDrawColor(color); err != nil {
+		return err
+	}
+	return r.renderer.FillRectF(toFRect(rect))
+}
+
+func (r *SDLRenderer) DrawLine(from basic.Point, to basic.Point, color primitive.Color) error {
+	if err := r.setDrawColor(color); err != nil {
+		return err
+	}
+	return r.renderer.DrawLineF(from.X, from.Y, to.X, to.Y)
+}
+
+func (r *SDLRenderer) FillCircle(center basic.Point, radius float32, color primitive.Color) error {
+	if !gfx.FilledCircleColor(r.renderer, int32(center.X), int32(center.Y), int32(radius), toSDLColor(color)) {
+		return fmt.Errorf("cannot draw circle: %v", sdl.GetError())
+	}
+	return nil
+}
+
+func (r *SDLRenderer) FillPolygon(points []basic.Point, color primitive.Color) error {
+	vx := make([]int16, len(points))
+	vy := make([]int16, len(points))
+	for i, p := range points {
+		vx[i] = int16(p.X)
+		vy[i] = int16(p.Y)
+	}
+
+	if !gfx.FilledPolygonColor(r.renderer, vx, vy, toSDLColor(color)) {
+		return fmt.Errorf("cannot draw polygon: %v", sdl.GetError())
+	}
+	return nil
+}
+
+func (r *SDLRenderer) DrawTexture(img *Image, src *basic.Rect, dst basic.Rect, transform TextureTransform) error {
+	if img == nil {
+		return fmt.Errorf("image is not loaded")
+	}
+
+	tx, ok := r.textures.Get(img)
+	if !ok {
+		var err error
+		tx, err = r.createTexture(img)
+		if err != nil {
+			return err
+		}
+		r.textures.Put(img, tx)
+	}
+
+	return r.copy(tx, src, dst, transform, white)
+}
+
+func (r *SDLRenderer) DrawText(text string, font Font, color primitive.Color, dst basic.Rect, transform TextureTransform) error {
+	if font == nil {
+		return fmt.Errorf("font is not loaded")
+	}
+
+	key := TextKey{Font: font, Text: text}
+	tx, ok := r.texts.Get(key)
+	if !ok {
+		img, err := font.RenderText(text)
+		if err != nil {
+			return err
+		}
+
+		tx, err = r.createTexture(img)
+		if err != nil {
+			return err
+		}
+		r.texts.Put(key, tx)
+	}
+
+	return r.copy(tx, nil, dst, transform, color)
+}
+
+func (r *SDLRenderer) SetClip(rect *basic.Rect) error {
+	if rect == nil {
+		return r.renderer.SetClipRect(nil)
+	}
+	return r.renderer.SetClipRect(&sdl.Rect{
+		X: int32(rect.X),
+		Y: int32(rect.Y),
+		W: int32(rect.Width),
+		H: int32(rect.Height),
+	})
+}
+
+func (r *SDLRenderer) Present() error {
+	r.renderer.Present()
+	r.textures.NextFrame()
+	r.texts.NextFrame()
+	return nil
+}
+
+// ReadPixels reads content of render target. When rendering to a window, content may be undefined after Present
+// on some drivers.
+func (r *SDLRenderer) ReadPixels() (*image.RGBA, error) {
+	w, h, err := r.renderer.GetOutputSize()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get renderer output size: %w", err)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, int(w), int(h)))
+	if w == 0 || h == 0 {
+		return img, nil
+	}
+
+	err = r.renderer.ReadPixels(nil, uint32(sdl.PIXELFORMAT_RGBA32), unsafe.Pointer(&img.Pix[0]), img.Stride)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read pixels: %w", err)
+	}
+
+	return img, nil
+}
+
+func (r *SDLRenderer) GetOutputSize() basic.Size {
+	w, h, err := r.renderer.GetOutputSize()
+	if err != nil {
+		fmt.Println(fmt.Errorf("cannot get renderer output size: %v", err))
+		return basic.Size{}
+	}
+	return basic.Size{Width: float32(w), Height: float32(h)}
+}
+
+func (r *SDLRenderer) Destroy() {
+	r.textures.Clear()
+	r.texts.Clear()
+	r.renderer.Destroy()
+}
+
+// createTexture uploads pixels of image into new texture.
+func (r *SDLRenderer) createTexture(img *Image) (*sdl.Texture, error) {
+	pixels := img.GetPixels()
+	w, h := pixels.Bounds().Dx(), pixels.Bounds().Dy()
+	if w == 0 || h == 0 {
+		return nil, fmt.Errorf("cannot create texture of empty image")
+	}
+
+	// pixels are copied, because SDL cannot keep pointer to Go memory
+	surf, err := sdl.CreateRGBSurfaceWithFormat(0, int32(w), int32(h), 32, uint32(sdl.PIXELFORMAT_RGBA32))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create surface: %w", err)
+	}
+	defer surf.Free()
+
+	if err := surf.Lock(); err != nil {
+		return nil, fmt.Errorf("cannot lock surface: %w", err)
+	}
+	dst, pitch := surf.Pixels(), int(surf.Pitch)
+	for y := 0; y < h; y++ {
+		copy(dst[y*pitch:y*pitch+w*4], pixels.Pix[y*pixels.Stride:y*pixels.Stride+w*4])
+	}
+	surf.Unlock()
+
+	return r.renderer.CreateTextureFromSurface(surf)
+}
+
+// copy draws texture multiplied by color, using CopyEx only when texture is rotated or flipped.
+func (r *SDLRenderer) copy(tx *sdl.Texture, src *basic.Rect, dst basic.Rect, transform TextureTransform, color primitive.Color) error {
+	// textures are cached, so color and alpha modulation are set on every draw
+	if err := tx.SetColorMod(color.R, color.G, color.B); err != nil {
+		return err
+	}
+	if err := tx.SetAlphaMod(uint8(float32(color.A) * (1 - min(max(transform.Transparency, 0), 1)))); err != nil {
+		return err
+	}
+
+	if transform.Angle == 0 && !transform.FlipHorizontal && !transform.FlipVertical {
+		return r.renderer.CopyF(tx, toRect(src), toFRect(dst))
+	}
+
+	var flip sdl.RendererFlip = sdl.FLIP_NONE
+	if transform.FlipHorizontal {
+		flip |= sdl.FLIP_HORIZONTAL
+	}
+	if transform.FlipVertical {
+		flip |= sdl.FLIP_VERTICAL
+	}
+
+	return r.renderer.CopyExF(
+		tx,
+		toRect(src),
+		toFRect(dst),
+		float64(transform.Angle),
+		&sdl.FPoint{X: transform.Center.X, Y: transform.Center.Y},
+		flip,
+	)
+}
+
+func (r *SDLRenderer) setDrawColor(color primitive.Color) error {
+	if err := r.renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND); err != nil {
+		return err
+	}
+	return r.renderer.SetDrawColor(color.R, color.G, color.B, color.A)
+}
+
+// white is a color of texture drawn as is.
+var white = primitive.Color{R: 255, G: 255, B: 255, A: 255}
+
+func toSDLColor(color primitive.Color) sdl.Color {
+	return sdl.Color{R: color.R, G: color.G, B: color.B, A: color.A}
+}
+
+func destroyTexture(tx *sdl.Texture) {
+	tx.Destroy()
+}
+
+func toFRect(rect basic.Rect) *sdl.FRect {
+	return &sdl.FRect{X: rect.X, Y: rect.Y, W: rect.Width, H: rect.Height}
+}
+
+func toRect(rect *basic.Rect) *sdl.Rect {
+	if rect == nil {
+		return nil
+	}
+	return &sdl.Rect{X: int32(rect.X), Y: int32(rect.Y), W: int32(rect.Width), H: int32(rect.Height)}
+}
@@ -0,0 +1,311 @@
+// Package software implements render.Renderer backend, which draws with pure Go code into image in memory.
+// It does not need cgo, window or GPU, so it is useful for tests and offscreen rendering.
+package software
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"github.com/SemyonHoyrish/GoPlayEngine/render"
+	"image"
+	"math"
+)
+
+// Renderer implements render.Renderer, which draws into image in memory, have to be initialized with NewRenderer.
+// Text is rasterized by render.Font, so fonts can be implemented without SDL_ttf too.
+type Renderer struct {
+	back  *image.RGBA
+	front *image.RGBA
+	clip  image.Rectangle
+
+	// rasterized text
+	texts *render.Cache[render.TextKey, *render.Image]
+}
+
+// NewRenderer creates Renderer with render target of provided size.
+func NewRenderer(width int, height int) *Renderer {
+	bounds := image.Rect(0, 0, width, height)
+	return &Renderer{
+		back:  image.NewRGBA(bounds),
+		front: image.NewRGBA(bounds),
+		clip:  bounds,
+		texts: render.NewCache[render.TextKey, *render.Image](nil),
+	}
+}
+
+// GetImage returns frame shown by last Present. Returned image is owned by renderer and is overwritten by next Present.
+func (r *Renderer) GetImage() *image.RGBA {
+	return r.front
+}
+
+func (r *Renderer) Clear(color primitive.Color) error {
+	pix := r.back.Pix
+	for i := 0; i < len(pix); i += 4 {
+		pix[i+0] = uint8(uint32(color.R) * uint32(color.A) / 255)
+		pix[i+1] = uint8(uint32(color.G) * uint32(color.A) / 255)
+		pix[i+2] = uint8(uint32(color.B) * uint32(color.A) / 255)
+		pix[i+3] = color.A
+	}
+	return nil
+}
+
+func (r *Renderer) FillRect(rect basic.Rect, color primitive.Color) error {
+	area := r.pixelRect(rect).Intersect(r.clip)
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			r.blend(x, y, color)
+		}
+	}
+	return nil
+}
+
+func (r *Renderer) DrawLine(from basic.Point, to basic.Point, color primitive.Color) error {
+	x0, y0 := int(math.Floor(float64(from.X))), int(math.Floor(float64(from.Y)))
+	x1, y1 := int(math.Floor(float64(to.X))), int(math.Floor(float64(to.Y)))
+
+	dx, sx := abs(x1-x0), 1
+	if x0 > x1 {
+		sx = -1
+	}
+	dy, sy := -abs(y1-y0), 1
+	if y0 > y1 {
+		sy = -1
+	}
+
+	e := dx + dy
+	for {
+		if (image.Point{X: x0, Y: y0}).In(r.clip) {
+			r.blend(x0, y0, color)
+		}
+		if x0 == x1 && y0 == y1 {
+			return nil
+		}
+		if e2 := 2 * e; e2 >= dy {
+			e += dy
+			x0 += sx
+		} else {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func (r *Renderer) FillCircle(center basic.Point, radius float32, color primitive.Color) error {
+	area := r.pixelRect(basic.Rect{
+		X:      center.X - radius,
+		Y:      center.Y - radius,
+		Width:  radius * 2,
+		Height: radius * 2,
+	}).Intersect(r.clip)
+
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			dx := float32(x) + 0.5 - center.X
+			dy := float32(y) + 0.5 - center.Y
+			if dx*dx+dy*dy <= radius*radius {
+				r.blend(x, y, color)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Renderer) FillPolygon(points []basic.Point, color primitive.Color) error {
+	if len(points) < 3 {
+		return nil
+	}
+
+	minY, maxY := points[0].Y, points[0].Y
+	for _, p := range points[1:] {
+		minY = min(minY, p.Y)
+		maxY = max(maxY, p.Y)
+	}
+
+	top := max(int(math.Round(float64(minY))), r.clip.Min.Y)
+	bottom := min(int(math.Round(float64(maxY))), r.clip.Max.Y)
+
+	// Scanline fill, pixels which centers are between left and right edges of convex polygon are filled.
+	for y := top; y < bottom; y++ {
+		cy := float32(y) + 0.5
+		left, right := float32(math.Inf(1)), float32(math.Inf(-1))
+
+		for i := range points {
+			a, b := points[i], points[(i+1)%len(points)]
+			if (a.Y <= cy && b.Y > cy) || (b.Y <= cy && a.Y > cy) {
+				x := a.X + (cy-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+				left = min(left, x)
+				right = max(right, x)
+			}
+		}
+		if left > right {
+			continue
+		}
+
+		from := max(int(math.Round(float64(left))), r.clip.Min.X)
+		to := min(int(math.Round(float64(right))), r.clip.Max.X)
+		for x := from; x < to; x++ {
+			r.blend(x, y, color)
+		}
+	}
+	return nil
+}
+
+func (r *Renderer) DrawTexture(img *render.Image, src *basic.Rect, dst basic.Rect, transform render.TextureTransform) error {
+	if img == nil {
+		return fmt.Errorf("image is not loaded")
+	}
+
+	r.drawImage(img.GetPixels(), src, dst, transform, white)
+	return nil
+}
+
+func (r *Renderer) DrawText(text string, font render.Font, color primitive.Color, dst basic.Rect, transform render.TextureTransform) error {
+	if font == nil {
+		return fmt.Errorf("font is not loaded")
+	}
+
+	key := render.TextKey{Font: font, Text: text}
+	img, ok := r.texts.Get(key)
+	if !ok {
+		var err error
+		img, err = font.RenderText(text)
+		if err != nil {
+			return err
+		}
+		r.texts.Put(key, img)
+	}
+
+	r.drawImage(img.GetPixels(), nil, dst, transform, color)
+	return nil
+}
+
+func (r *Renderer) SetClip(rect *basic.Rect) error {
+	if rect == nil {
+		r.clip = r.back.Bounds()
+	} else {
+		r.clip = r.pixelRect(*rect).Intersect(r.back.Bounds())
+	}
+	return nil
+}
+
+func (r *Renderer) Present() error {
+	copy(r.front.Pix, r.back.Pix)
+	r.texts.NextFrame()
+	return nil
+}
+
+// ReadPixels returns copy of the frame shown by last Present.
+func (r *Renderer) ReadPixels() (*image.RGBA, error) {
+	img := image.NewRGBA(r.front.Bounds())
+	copy(img.Pix, r.front.Pix)
+	return img, nil
+}
+
+func (r *Renderer) GetOutputSize() basic.Size {
+	return basic.Size{Width: float32(r.back.Bounds().Dx()), Height: float32(r.back.Bounds().Dy())}
+}
+
+func (r *Renderer) Destroy() {
+	r.texts.Clear()
+}
+
+// drawImage draws src part of img multiplied by color, stretched to dst and transformed,
+// using nearest neighbour sampling.
+func (r *Renderer) drawImage(img *image.NRGBA, src *basic.Rect, dst basic.Rect, transform render.TextureTransform, color primitive.Color) {
+	source := basic.Rect{Width: float32(img.Bounds().Dx()), Height: float32(img.Bounds().Dy())}
+	if src != nil {
+		source = *src
+	}
+	if dst.Width == 0 || dst.Height == 0 {
+		return
+	}
+
+	opacity := float32(color.A) / 255 * (1 - min(max(transform.Transparency, 0), 1))
+
+	// toScreen maps point relative to dst to the screen, toDst is its inverse
+	center := basic.Point{X: dst.X + transform.Center.X, Y: dst.Y + transform.Center.Y}
+	toScreen := basic.TranslationTransform(center.X, center.Y).
+		Multiply(basic.RotationTransform(transform.Angle)).
+		Multiply(basic.TranslationTransform(-transform.Center.X, -transform.Center.Y))
+	toDst, _ := toScreen.Inverse()
+
+	corners := []basic.Point{{}, {X: dst.Width}, {X: dst.Width, Y: dst.Height}, {Y: dst.Height}}
+	minP, maxP := toScreen.Apply(corners[0]), toScreen.Apply(corners[0])
+	for _, corner := range corners[1:] {
+		p := toScreen.Apply(corner)
+		minP = basic.Point{X: min(minP.X, p.X), Y: min(minP.Y, p.Y)}
+		maxP = basic.Point{X: max(maxP.X, p.X), Y: max(maxP.Y, p.Y)}
+	}
+	area := image.Rect(
+		int(math.Floor(float64(minP.X))),
+		int(math.Floor(float64(minP.Y))),
+		int(math.Ceil(float64(maxP.X))),
+		int(math.Ceil(float64(maxP.Y))),
+	)
+	area = area.Intersect(r.clip)
+
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			p := toDst.Apply(basic.Point{X: float32(x) + 0.5, Y: float32(y) + 0.5})
+			if p.X < 0 || p.Y < 0 || p.X >= dst.Width || p.Y >= dst.Height {
+				continue
+			}
+			if transform.FlipHorizontal {
+				p.X = dst.Width - p.X
+			}
+			if transform.FlipVertical {
+				p.Y = dst.Height - p.Y
+			}
+
+			sx := int(math.Floor(float64(source.X + p.X*source.Width/dst.Width)))
+			sy := int(math.Floor(float64(source.Y + p.Y*source.Height/dst.Height)))
+			if !(image.Point{X: sx, Y: sy}).In(img.Bounds()) {
+				continue
+			}
+			c := img.NRGBAAt(sx, sy)
+			r.blend(x, y, primitive.Color{
+				R: uint8(uint32(c.R) * uint32(color.R) / 255),
+				G: uint8(uint32(c.G) * uint32(color.G) / 255),
+				B: uint8(uint32(c.B) * uint32(color.B) / 255),
+				A: uint8(float32(c.A) * opacity),
+			})
+		}
+	}
+}
+
+// blend draws non-premultiplied color over pixel of back buffer.
+func (r *Renderer) blend(x int, y int, color primitive.Color) {
+	a := uint32(color.A)
+	if a == 0 {
+		return
+	}
+
+	i := r.back.PixOffset(x, y)
+	p := r.back.Pix[i : i+4 : i+4]
+	ia := 255 - a
+
+	p[0] = uint8((uint32(color.R)*a + uint32(p[0])*ia) / 255)
+	p[1] = uint8((uint32(color.G)*a + uint32(p[1])*ia) / 255)
+	p[2] = uint8((uint32(color.B)*a + uint32(p[2])*ia) / 255)
+	p[3] = uint8(a + uint32(p[3])*ia/255)
+}
+
+// pixelRect returns pixels, which centers are inside rect.
+func (r *Renderer) pixelRect(rect basic.Rect) image.Rectangle {
+	return image.Rect(
+		int(math.Round(float64(rect.X))),
+		int(math.Round(float64(rect.Y))),
+		int(math.Round(float64(rect.X+rect.Width))),
+		int(math.Round(float64(rect.Y+rect.Height))),
+	)
+}
+
+// white is a color of image drawn as is.
+var white = primitive.Color{R: 255, G: 255, B: 255, A: 255}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
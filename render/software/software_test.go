@@ -0,0 +1,73 @@
+package software
+
+import (
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"github.com/SemyonHoyrish/GoPlayEngine/render"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// countingFont rasterizes any text as white square and counts rasterizations.
+type countingFont struct {
+	rendered int
+}
+
+func (f *countingFont) RenderText(text string) (*render.Image, error) {
+	f.rendered++
+	pixels := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for i := range pixels.Pix {
+		pixels.Pix[i] = 255
+	}
+	return render.NewImage(pixels), nil
+}
+
+func (f *countingFont) MeasureText(text string) (basic.Size, error) {
+	return basic.Size{Width: 4, Height: 4}, nil
+}
+
+func TestTextColorDoesNotRasterizeAgain(t *testing.T) {
+	r := NewRenderer(4, 4)
+	font := &countingFont{}
+	dst := basic.Rect{Width: 4, Height: 4}
+
+	colors := []primitive.Color{
+		{R: 255, G: 0, B: 0, A: 255},
+		{R: 0, G: 128, B: 255, A: 255},
+		{R: 200, G: 100, B: 50, A: 255},
+	}
+	for _, c := range colors {
+		r.Clear(primitive.Color{A: 255})
+		if err := r.DrawText("text", font, c, dst, render.TextureTransform{}); err != nil {
+			t.Fatal(err)
+		}
+		r.Present()
+
+		if got := r.GetImage().RGBAAt(1, 1); got != (color.RGBA{R: c.R, G: c.G, B: c.B, A: 255}) {
+			t.Errorf("text is drawn with %v, expected %v", got, c)
+		}
+	}
+
+	if font.rendered != 1 {
+		t.Errorf("text was rasterized %d times, expected once", font.rendered)
+	}
+
+	r.DrawText("other text", font, colors[0], dst, render.TextureTransform{})
+	if font.rendered != 2 {
+		t.Errorf("changed text was not rasterized")
+	}
+}
+
+func TestTextColorAlpha(t *testing.T) {
+	r := NewRenderer(4, 4)
+	r.Clear(primitive.Color{A: 255})
+	transform := render.TextureTransform{Transparency: 0.5}
+	r.DrawText("text", &countingFont{}, primitive.Color{R: 255, G: 255, B: 255, A: 128}, basic.Rect{Width: 4, Height: 4}, transform)
+	r.Present()
+
+	// alpha of color and transparency are multiplied
+	if got := r.GetImage().RGBAAt(1, 1); got.R < 62 || got.R > 66 {
+		t.Errorf("text is drawn with %v, expected a quarter of white", got)
+	}
+}
@@ -2,44 +2,69 @@ package resource
 
 import (
 	"fmt"
-	"github.com/veandco/go-sdl2/ttf"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/render"
 )
 
 // Font used to link path to file on disk and its loaded content,
 // have to be initialized with NewFont
+//
+// With cgo fonts are loaded and rasterized by SDL_ttf, without cgo by Go OpenType rasterizer.
 type Font struct {
 	path string
 
-	loaded   map[int]bool
-	ttfFonts map[int]*ttf.Font
+	loaded map[int]bool
+	faces  map[int]*fontFace
 }
 
 func NewFont(path string) *Font {
 	return &Font{
-		path:     path,
-		loaded:   make(map[int]bool),
-		ttfFonts: make(map[int]*ttf.Font),
+		path:   path,
+		loaded: make(map[int]bool),
+		faces:  make(map[int]*fontFace),
 	}
 }
 
 // Reload load content of file and stores it as font in memory.
 // Reload called automatically if it was not called before.
 func (f *Font) Reload(fontSize int) {
-	font, err := ttf.OpenFont(f.path, fontSize)
+	face, err := loadFontFace(f.path, fontSize)
 	if err != nil {
 		fmt.Println(fmt.Errorf("cannot reload font (%s): %v", f.path, err))
 	} else {
-		f.ttfFonts[fontSize] = font
+		f.faces[fontSize] = face
 		f.loaded[fontSize] = true
 	}
 }
 
-// GetTTFFont is an internal function, returns font representation used to render it.
-func (f *Font) GetTTFFont(fontSize int) *ttf.Font {
+// GetPath returns path to file of the font.
+func (f *Font) GetPath() string {
+	return f.path
+}
+
+// GetFace is an internal function, returns font of provided size used to render text, nil if font is not loaded.
+func (f *Font) GetFace(fontSize int) render.Font {
+	face := f.getFace(fontSize)
+	if face == nil {
+		return nil
+	}
+	return face
+}
+
+// MeasureText returns size of text rendered with font of provided size.
+func (f *Font) MeasureText(fontSize int, text string) (basic.Size, error) {
+	face := f.getFace(fontSize)
+	if face == nil {
+		return basic.Size{}, fmt.Errorf("font (%s) is not loaded", f.path)
+	}
+	return face.MeasureText(text)
+}
+
+func (f *Font) getFace(fontSize int) *fontFace {
 	loaded, ok := f.loaded[fontSize]
 	if !ok || !loaded {
 		f.Reload(fontSize)
 	}
 
-	return f.ttfFonts[fontSize]
+	return f.faces[fontSize]
 }
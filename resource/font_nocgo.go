@@ -0,0 +1,74 @@
+//go:build !cgo
+
+package resource
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/render"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"os"
+)
+
+// fontFace implements render.Font with Go OpenType rasterizer, used when SDL_ttf is not available without cgo.
+type fontFace struct {
+	face font.Face
+}
+
+func loadFontFace(path string, fontSize int) (*fontFace, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := opentype.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	// size is in points at 72 DPI, so it is a size in pixels, the same as in SDL_ttf
+	face, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: float64(fontSize), DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, err
+	}
+	return &fontFace{face: face}, nil
+}
+
+func (f *fontFace) RenderText(text string) (*render.Image, error) {
+	size, err := f.MeasureText(text)
+	if err != nil {
+		return nil, err
+	}
+	if size.Width == 0 {
+		return nil, fmt.Errorf("text has zero width")
+	}
+
+	mask := image.NewAlpha(image.Rect(0, 0, int(size.Width), int(size.Height)))
+	drawer := font.Drawer{
+		Dst:  mask,
+		Src:  image.Opaque,
+		Face: f.face,
+		Dot:  fixed.P(0, f.face.Metrics().Ascent.Ceil()),
+	}
+	drawer.DrawString(text)
+
+	// text is white, its coverage is stored in alpha channel
+	pixels := image.NewNRGBA(mask.Rect)
+	for i, a := range mask.Pix {
+		pixels.Pix[i*4+0] = 255
+		pixels.Pix[i*4+1] = 255
+		pixels.Pix[i*4+2] = 255
+		pixels.Pix[i*4+3] = a
+	}
+	return render.NewImage(pixels), nil
+}
+
+func (f *fontFace) MeasureText(text string) (basic.Size, error) {
+	metrics := f.face.Metrics()
+	width := font.MeasureString(f.face, text).Ceil()
+	height := metrics.Ascent.Ceil() + metrics.Descent.Ceil()
+	return basic.Size{Width: float32(width), Height: float32(height)}, nil
+}
@@ -0,0 +1,55 @@
+//go:build cgo
+
+package resource
+
+import (
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/render"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// fontFace implements render.Font with SDL_ttf.
+type fontFace struct {
+	font *ttf.Font
+}
+
+func loadFontFace(path string, fontSize int) (*fontFace, error) {
+	font, err := ttf.OpenFont(path, fontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &fontFace{font: font}, nil
+}
+
+// GetTTFFont is an internal function, returns font representation used by SDL_ttf.
+// It is available only with cgo.
+func (f *Font) GetTTFFont(fontSize int) *ttf.Font {
+	face := f.getFace(fontSize)
+	if face == nil {
+		return nil
+	}
+	return face.font
+}
+
+func (f *fontFace) RenderText(text string) (*render.Image, error) {
+	surf, err := f.font.RenderUTF8Blended(text, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+	if err != nil {
+		return nil, err
+	}
+	defer surf.Free()
+
+	pixels, err := surfaceToNRGBA(surf)
+	if err != nil {
+		return nil, err
+	}
+	return render.NewImage(pixels), nil
+}
+
+func (f *fontFace) MeasureText(text string) (basic.Size, error) {
+	w, h, err := f.font.SizeUTF8(text)
+	if err != nil {
+		return basic.Size{}, err
+	}
+	return basic.Size{Width: float32(w), Height: float32(h)}, nil
+}
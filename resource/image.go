@@ -2,44 +2,79 @@ package resource
 
 import (
 	"fmt"
-	"github.com/veandco/go-sdl2/img"
-	"github.com/veandco/go-sdl2/sdl"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/render"
 )
 
 // Image used to link path to file on disk and its loaded content,
-// have to be initialized with NewFont
+// have to be initialized with NewImage
+//
+// With cgo images are loaded by SDL_image, without cgo they are decoded by Go decoders (PNG, JPEG and GIF).
 type Image struct {
 	path string
 
-	loaded  bool
-	surface *sdl.Surface
+	loaded bool
+	size   basic.Size
+	// content of the file, loaded by SDL_image or Go decoders
+	native nativeImage
+	// pixels of the image, converted when image is drawn for the first time
+	image *render.Image
 }
 
 func NewImage(path string) *Image {
 	return &Image{
-		path:    path,
-		loaded:  false,
-		surface: nil,
+		path:   path,
+		loaded: false,
 	}
 }
 
 // Reload load content of file and stores it as pixel data in memory.
 // Reload called automatically if it was not called before.
 func (i *Image) Reload() {
-	surf, err := img.Load(i.path)
+	native, size, err := loadImage(i.path)
 	if err != nil {
 		fmt.Println(fmt.Errorf("cannot reload image (%s): %v", i.path, err))
 	} else {
-		i.surface = surf
+		i.native = native
+		i.size = size
+		i.image = nil
 		i.loaded = true
 	}
 }
 
-// GetSurface is an internal function, returns image representation used to render it.
-func (i *Image) GetSurface() *sdl.Surface {
+// GetPath returns path to file of the image.
+func (i *Image) GetPath() string {
+	return i.path
+}
+
+// GetSize returns size of the image in pixels, zero size if image is not loaded.
+func (i *Image) GetSize() basic.Size {
 	if !i.loaded {
 		i.Reload()
 	}
 
-	return i.surface
+	return i.size
+}
+
+// GetImage is an internal function, returns image representation used to render it, nil if image is not loaded.
+func (i *Image) GetImage() *render.Image {
+	if i.image != nil {
+		return i.image
+	}
+
+	if !i.loaded {
+		i.Reload()
+	}
+	if !i.loaded {
+		return nil
+	}
+
+	pixels, err := i.native.toNRGBA()
+	if err != nil {
+		fmt.Println(fmt.Errorf("cannot convert image (%s): %v", i.path, err))
+		return nil
+	}
+
+	i.image = render.NewImage(pixels)
+	return i.image
 }
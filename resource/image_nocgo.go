@@ -0,0 +1,41 @@
+//go:build !cgo
+
+package resource
+
+import (
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+)
+
+// nativeImage is an image decoded by Go decoders, used when SDL_image is not available without cgo.
+type nativeImage struct {
+	pixels *image.NRGBA
+}
+
+func loadImage(path string) (nativeImage, basic.Size, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nativeImage{}, basic.Size{}, err
+	}
+	defer file.Close()
+
+	decoded, _, err := image.Decode(file)
+	if err != nil {
+		return nativeImage{}, basic.Size{}, err
+	}
+
+	bounds := decoded.Bounds()
+	pixels := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(pixels, pixels.Bounds(), decoded, bounds.Min, draw.Src)
+
+	return nativeImage{pixels: pixels}, basic.Size{Width: float32(bounds.Dx()), Height: float32(bounds.Dy())}, nil
+}
+
+func (n nativeImage) toNRGBA() (*image.NRGBA, error) {
+	return n.pixels, nil
+}
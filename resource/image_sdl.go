@@ -0,0 +1,61 @@
+//go:build cgo
+
+package resource
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/veandco/go-sdl2/img"
+	"github.com/veandco/go-sdl2/sdl"
+	"image"
+)
+
+// nativeImage is an image loaded by SDL_image.
+type nativeImage struct {
+	surface *sdl.Surface
+}
+
+func loadImage(path string) (nativeImage, basic.Size, error) {
+	surf, err := img.Load(path)
+	if err != nil {
+		return nativeImage{}, basic.Size{}, err
+	}
+	return nativeImage{surface: surf}, basic.Size{Width: float32(surf.W), Height: float32(surf.H)}, nil
+}
+
+func (n nativeImage) toNRGBA() (*image.NRGBA, error) {
+	return surfaceToNRGBA(n.surface)
+}
+
+// GetSurface is an internal function, returns image representation used by SDL (e.g. for window icon).
+// It is available only with cgo.
+func (i *Image) GetSurface() *sdl.Surface {
+	if !i.loaded {
+		i.Reload()
+	}
+
+	return i.native.surface
+}
+
+// surfaceToNRGBA copies pixels of SDL surface into Go image.
+func surfaceToNRGBA(surf *sdl.Surface) (*image.NRGBA, error) {
+	converted, err := surf.ConvertFormat(uint32(sdl.PIXELFORMAT_RGBA32), 0)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert surface: %w", err)
+	}
+	defer converted.Free()
+
+	if err := converted.Lock(); err != nil {
+		return nil, fmt.Errorf("cannot lock surface: %w", err)
+	}
+	defer converted.Unlock()
+
+	w, h, pitch := int(converted.W), int(converted.H), int(converted.Pitch)
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	pixels := converted.Pixels()
+	for y := 0; y < h; y++ {
+		copy(img.Pix[y*img.Stride:y*img.Stride+w*4], pixels[y*pitch:y*pitch+w*4])
+	}
+
+	return img, nil
+}
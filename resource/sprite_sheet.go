@@ -0,0 +1,260 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"os"
+	"path/filepath"
+)
+
+// Region is a part of sprite sheet image, used as a single sprite or animation frame.
+type Region struct {
+	// Name of the region, empty for regions of grid sprite sheet.
+	Name string
+	// Rect in pixels of image.
+	Rect basic.Rect
+	// Duration of animation frame in seconds, loaded from Aseprite metadata, 0 if not provided.
+	Duration float64
+}
+
+// FrameTag is a named range of regions, loaded from Aseprite metadata.
+type FrameTag struct {
+	Name string
+	// From and To are indexes of first and last (inclusive) regions.
+	From int
+	To   int
+	// Direction is "forward", "reverse" or "pingpong".
+	Direction string
+}
+
+// SpriteSheet (texture atlas) slices one Image into indexed and optionally named regions.
+// SpriteSheet have to be initialized with NewSpriteSheet, NewGridSpriteSheet or LoadSpriteSheet.
+type SpriteSheet struct {
+	image *Image
+
+	regions []Region
+	names   map[string]int
+	tags    []FrameTag
+}
+
+// NewSpriteSheet creates sprite sheet without regions, regions are added with AddRegion.
+func NewSpriteSheet(image *Image) *SpriteSheet {
+	return &SpriteSheet{
+		image: image,
+		names: make(map[string]int),
+	}
+}
+
+// NewGridSpriteSheet creates sprite sheet from image divided into grid of frames of the same size.
+// Margin is a space around grid, spacing is a space between frames, both in pixels.
+// Regions are indexed row by row, starting from top left one.
+func NewGridSpriteSheet(image *Image, frameWidth int, frameHeight int, margin int, spacing int) (*SpriteSheet, error) {
+	if frameWidth <= 0 || frameHeight <= 0 {
+		return nil, fmt.Errorf("frame size must be positive, got %dx%d", frameWidth, frameHeight)
+	}
+
+	size := image.GetSize()
+	if !image.loaded {
+		return nil, fmt.Errorf("image (%s) is not loaded", image.path)
+	}
+
+	sheet := NewSpriteSheet(image)
+	for y := margin; y+frameHeight <= int(size.Height)-margin; y += frameHeight + spacing {
+		for x := margin; x+frameWidth <= int(size.Width)-margin; x += frameWidth + spacing {
+			sheet.AddRegion("", basic.Rect{
+				X:      float32(x),
+				Y:      float32(y),
+				Width:  float32(frameWidth),
+				Height: float32(frameHeight),
+			})
+		}
+	}
+
+	return sheet, nil
+}
+
+// LoadSpriteSheet loads regions from JSON file exported by TexturePacker or Aseprite,
+// both "hash" and "array" layouts of frames are supported. If image is nil,
+// image is loaded from path stored in metadata, relative to JSON file.
+func LoadSpriteSheet(path string, image *Image) (*SpriteSheet, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read sprite sheet (%s): %w", path, err)
+	}
+
+	var file atlasFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("cannot parse sprite sheet (%s): %w", path, err)
+	}
+
+	frames, err := file.parseFrames()
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse frames of sprite sheet (%s): %w", path, err)
+	}
+
+	if image == nil {
+		if file.Meta.Image == "" {
+			return nil, fmt.Errorf("sprite sheet (%s) has no image in metadata", path)
+		}
+		image = NewImage(filepath.Join(filepath.Dir(path), file.Meta.Image))
+	}
+
+	sheet := NewSpriteSheet(image)
+	for _, frame := range frames {
+		if frame.Rotated {
+			return nil, fmt.Errorf("sprite sheet (%s) has rotated frame %q, rotated frames are not supported", path, frame.Filename)
+		}
+
+		index := sheet.AddRegion(frame.Filename, basic.Rect{
+			X:      frame.Frame.X,
+			Y:      frame.Frame.Y,
+			Width:  frame.Frame.W,
+			Height: frame.Frame.H,
+		})
+		sheet.regions[index].Duration = frame.Duration / 1000
+	}
+
+	for _, tag := range file.Meta.FrameTags {
+		if tag.From < 0 || tag.To >= len(sheet.regions) || tag.From > tag.To {
+			return nil, fmt.Errorf("sprite sheet (%s) has tag %q with invalid range %d..%d", path, tag.Name, tag.From, tag.To)
+		}
+		sheet.tags = append(sheet.tags, FrameTag{
+			Name:      tag.Name,
+			From:      tag.From,
+			To:        tag.To,
+			Direction: tag.Direction,
+		})
+	}
+
+	return sheet, nil
+}
+
+// GetImage returns image sliced by sprite sheet.
+func (s *SpriteSheet) GetImage() *Image {
+	return s.image
+}
+
+// AddRegion adds region and returns its index, name can be empty.
+// Region with the same name as existing one replaces it in lookup by name.
+func (s *SpriteSheet) AddRegion(name string, rect basic.Rect) int {
+	s.regions = append(s.regions, Region{Name: name, Rect: rect})
+	index := len(s.regions) - 1
+	if name != "" {
+		s.names[name] = index
+	}
+	return index
+}
+
+// GetRegion returns region by index, false if there is no such region.
+func (s *SpriteSheet) GetRegion(index int) (Region, bool) {
+	if index < 0 || index >= len(s.regions) {
+		return Region{}, false
+	}
+	return s.regions[index], true
+}
+
+// GetRegionByName returns region by name, false if there is no such region.
+func (s *SpriteSheet) GetRegionByName(name string) (Region, bool) {
+	index, ok := s.names[name]
+	if !ok {
+		return Region{}, false
+	}
+	return s.regions[index], true
+}
+
+// GetRegions returns all regions in order of their indexes.
+func (s *SpriteSheet) GetRegions() []Region {
+	return s.regions
+}
+
+// Len returns number of regions.
+func (s *SpriteSheet) Len() int {
+	return len(s.regions)
+}
+
+// GetTag returns frame tag by name, false if there is no such tag.
+func (s *SpriteSheet) GetTag(name string) (FrameTag, bool) {
+	for _, tag := range s.tags {
+		if tag.Name == name {
+			return tag, true
+		}
+	}
+	return FrameTag{}, false
+}
+
+// GetTags returns all frame tags.
+func (s *SpriteSheet) GetTags() []FrameTag {
+	return s.tags
+}
+
+// atlasFile is a JSON layout shared by TexturePacker and Aseprite.
+type atlasFile struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   struct {
+		Image     string `json:"image"`
+		FrameTags []struct {
+			Name      string `json:"name"`
+			From      int    `json:"from"`
+			To        int    `json:"to"`
+			Direction string `json:"direction"`
+		} `json:"frameTags"`
+	} `json:"meta"`
+}
+
+type atlasFrame struct {
+	Filename string `json:"filename"`
+	Frame    struct {
+		X float32 `json:"x"`
+		Y float32 `json:"y"`
+		W float32 `json:"w"`
+		H float32 `json:"h"`
+	} `json:"frame"`
+	Rotated bool `json:"rotated"`
+	// Duration in milliseconds
+	Duration float64 `json:"duration"`
+}
+
+// parseFrames parses frames stored either as array, or as object keyed by name, keeping order of object keys,
+// because Aseprite frame tags refer to frames by index.
+func (f *atlasFile) parseFrames() ([]atlasFrame, error) {
+	trimmed := bytes.TrimSpace(f.Frames)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("no frames")
+	}
+
+	if trimmed[0] == '[' {
+		var frames []atlasFrame
+		if err := json.Unmarshal(trimmed, &frames); err != nil {
+			return nil, err
+		}
+		return frames, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	var frames []atlasFrame
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected token %v", token)
+		}
+
+		var frame atlasFrame
+		if err := dec.Decode(&frame); err != nil {
+			return nil, err
+		}
+		frame.Filename = name
+		frames = append(frames, frame)
+	}
+
+	return frames, nil
+}
@@ -0,0 +1,70 @@
+// Package savegame stores game state in named slots on disk.
+//
+// Every slot is a JSON file containing any game state struct (encoded with encoding/json) and state of selected
+// scene nodes, matched by their names. Slots are written atomically, carry version of the game data,
+// which is upgraded with migrations on load, and SHA-256 checksum, which detects corrupted or truncated files.
+package savegame
+
+import (
+	"encoding/json"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/core"
+	"time"
+)
+
+// file is content of slot file, checksum is calculated from Version and Data bytes as they are stored.
+type file struct {
+	Version  int             `json:"version"`
+	SavedAt  time.Time       `json:"savedAt"`
+	Checksum string          `json:"checksum"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// data is a checked content of slot.
+type data struct {
+	State json.RawMessage      `json:"state"`
+	Nodes map[string]NodeState `json:"nodes,omitempty"`
+}
+
+// NodeState is stored state of a node.
+type NodeState struct {
+	Position basic.Point `json:"position"`
+	Rotation float32     `json:"rotation"`
+	Scale    basic.Point `json:"scale"`
+	Alpha    float32     `json:"alpha"`
+	// Text is stored for text nodes only.
+	Text *string `json:"text,omitempty"`
+}
+
+// GetNodeState returns state of the node to be stored.
+func GetNodeState(node *core.Node) NodeState {
+	state := NodeState{
+		Position: node.GetPosition(),
+		Rotation: node.GetRotation(),
+		Scale:    node.GetScale(),
+		Alpha:    node.GetAlpha(),
+	}
+	if info := node.GetTextInfo(); info != nil {
+		text := info.Text
+		state.Text = &text
+	}
+	return state
+}
+
+// Apply sets stored state to the node.
+func (s NodeState) Apply(node *core.Node) {
+	node.SetPosition(s.Position)
+	node.SetRotation(s.Rotation)
+	node.SetScale(s.Scale)
+	node.SetAlpha(s.Alpha)
+	if info := node.GetTextInfo(); info != nil && s.Text != nil {
+		info.Text = *s.Text
+	}
+}
+
+// SlotInfo describes stored slot.
+type SlotInfo struct {
+	Name    string
+	Version int
+	SavedAt time.Time
+}
@@ -0,0 +1,327 @@
+package savegame
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/core"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// slotExtension is extension of slot files in store directory.
+const slotExtension = ".save"
+
+// ErrNoSlot is returned by Load when slot does not exist.
+var ErrNoSlot = errors.New("save slot does not exist")
+
+// ErrCorrupted is returned by Load when checksum of slot does not match its content.
+var ErrCorrupted = errors.New("save slot is corrupted")
+
+// Migration upgrades stored game state from one version to the next one.
+type Migration func(state json.RawMessage) (json.RawMessage, error)
+
+// Store keeps save slots in a directory. Store have to be initialized with NewStore or NewStoreAt.
+type Store struct {
+	dir        string
+	version    int
+	migrations map[int]Migration
+}
+
+// NewStore creates store in saves directory of the game inside user data directory (see DataDir).
+func NewStore(game string) (*Store, error) {
+	dir, err := DataDir(game)
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreAt(filepath.Join(dir, "saves")), nil
+}
+
+// NewStoreAt creates store in directory, which is created on first save.
+// Version of game data is 1 until changed with SetVersion.
+func NewStoreAt(dir string) *Store {
+	return &Store{
+		dir:        dir,
+		version:    1,
+		migrations: make(map[int]Migration),
+	}
+}
+
+// DataDir returns data directory of the game: $XDG_DATA_HOME/game (~/.local/share/game by default) on Linux,
+// and user config directory of the system (see os.UserConfigDir) on other systems.
+func DataDir(game string) (string, error) {
+	if game == "" || strings.ContainsAny(game, `/\`) {
+		return "", fmt.Errorf("invalid game name %q", game)
+	}
+
+	if runtime.GOOS == "linux" {
+		if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+			return filepath.Join(dir, game), nil
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot find data directory: %w", err)
+		}
+		return filepath.Join(home, ".local", "share", game), nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find data directory: %w", err)
+	}
+	return filepath.Join(dir, game), nil
+}
+
+// GetDir returns directory of the store.
+func (s *Store) GetDir() string {
+	return s.dir
+}
+
+// SetVersion sets current version of game data, which is written to saved slots.
+// Slots of older versions are upgraded on load by migrations, slots of newer versions cannot be loaded.
+func (s *Store) SetVersion(version int) {
+	s.version = version
+}
+
+// GetVersion returns current version of game data.
+func (s *Store) GetVersion() int {
+	return s.version
+}
+
+// AddMigration sets migration upgrading game state of version from to version from+1.
+func (s *Store) AddMigration(from int, migration Migration) {
+	s.migrations[from] = migration
+}
+
+// Save stores state (encoded with encoding/json) and state of nodes (see GetNodeState) in slot, replacing it.
+// Nodes are stored by their names, so they have to be unique and not empty.
+// Slot is written to temporary file first, which then replaces the slot, so slot is never left partially written.
+func (s *Store) Save(slot string, state any, nodes ...*core.Node) error {
+	path, err := s.slotPath(slot)
+	if err != nil {
+		return err
+	}
+
+	d := data{}
+	d.State, err = json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("cannot encode state of slot %q: %w", slot, err)
+	}
+	if len(nodes) > 0 {
+		d.Nodes = make(map[string]NodeState, len(nodes))
+		for _, node := range nodes {
+			name := node.GetName()
+			if name == "" {
+				return fmt.Errorf("cannot save node without name (id=%d)", node.GetID())
+			}
+			if _, ok := d.Nodes[name]; ok {
+				return fmt.Errorf("cannot save several nodes with name %q", name)
+			}
+			d.Nodes[name] = GetNodeState(node)
+		}
+	}
+
+	raw, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("cannot encode slot %q: %w", slot, err)
+	}
+
+	content, err := json.Marshal(file{
+		Version:  s.version,
+		SavedAt:  time.Now().UTC(),
+		Checksum: checksum(s.version, raw),
+		Data:     raw,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot encode slot %q: %w", slot, err)
+	}
+
+	if err := writeAtomic(path, content); err != nil {
+		return fmt.Errorf("cannot write slot %q: %w", slot, err)
+	}
+	return nil
+}
+
+// Load reads slot into state (decoded with encoding/json) and applies stored state to nodes with the same names.
+// Nodes without stored state are not changed. Returns ErrNoSlot if slot does not exist,
+// and ErrCorrupted if slot was damaged.
+func (s *Store) Load(slot string, state any, nodes ...*core.Node) error {
+	f, err := s.readSlot(slot)
+	if err != nil {
+		return err
+	}
+
+	var d data
+	if err := json.Unmarshal(f.Data, &d); err != nil {
+		return fmt.Errorf("cannot decode slot %q: %w", slot, err)
+	}
+
+	if d.State, err = s.migrate(f.Version, d.State); err != nil {
+		return fmt.Errorf("cannot migrate slot %q: %w", slot, err)
+	}
+	if err := json.Unmarshal(d.State, state); err != nil {
+		return fmt.Errorf("cannot decode state of slot %q: %w", slot, err)
+	}
+
+	for _, node := range nodes {
+		if nodeState, ok := d.Nodes[node.GetName()]; ok {
+			nodeState.Apply(node)
+		}
+	}
+	return nil
+}
+
+// Exists returns true if slot exists.
+func (s *Store) Exists(slot string) bool {
+	path, err := s.slotPath(slot)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// Delete removes slot, does nothing if slot does not exist.
+func (s *Store) Delete(slot string) error {
+	path, err := s.slotPath(slot)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cannot delete slot %q: %w", slot, err)
+	}
+	return nil
+}
+
+// List returns valid slots of the store sorted by time of saving, the latest first.
+// Corrupted slots are not listed.
+func (s *Store) List() ([]SlotInfo, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot list slots: %w", err)
+	}
+
+	slots := make([]SlotInfo, 0)
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), slotExtension)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		f, err := s.readSlot(name)
+		if err != nil {
+			continue
+		}
+		slots = append(slots, SlotInfo{Name: name, Version: f.Version, SavedAt: f.SavedAt})
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].SavedAt.After(slots[j].SavedAt)
+	})
+	return slots, nil
+}
+
+// AutosaveHook returns function saving slot, which is intended to be used with Engine.AddExitHook.
+// State and nodes are saved as they are at the time of the call, errors are printed.
+func (s *Store) AutosaveHook(slot string, state any, nodes ...*core.Node) func() {
+	return func() {
+		if err := s.Save(slot, state, nodes...); err != nil {
+			fmt.Println(fmt.Errorf("cannot autosave: %w", err))
+		}
+	}
+}
+
+// readSlot reads slot file and validates its checksum.
+func (s *Store) readSlot(slot string) (file, error) {
+	path, err := s.slotPath(slot)
+	if err != nil {
+		return file{}, err
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return file{}, fmt.Errorf("%w: %q", ErrNoSlot, slot)
+	}
+	if err != nil {
+		return file{}, fmt.Errorf("cannot read slot %q: %w", slot, err)
+	}
+
+	var f file
+	if err := json.Unmarshal(content, &f); err != nil {
+		return file{}, fmt.Errorf("%w: %q: %v", ErrCorrupted, slot, err)
+	}
+	if f.Checksum != checksum(f.Version, f.Data) {
+		return file{}, fmt.Errorf("%w: %q: checksum mismatch", ErrCorrupted, slot)
+	}
+	return f, nil
+}
+
+// migrate upgrades state of version to current version of the store.
+func (s *Store) migrate(version int, state json.RawMessage) (json.RawMessage, error) {
+	if version > s.version {
+		return nil, fmt.Errorf("version %d is newer than current version %d", version, s.version)
+	}
+
+	for ; version < s.version; version++ {
+		migration, ok := s.migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from version %d", version)
+		}
+		var err error
+		if state, err = migration(state); err != nil {
+			return nil, fmt.Errorf("migration from version %d: %w", version, err)
+		}
+	}
+	return state, nil
+}
+
+func (s *Store) slotPath(slot string) (string, error) {
+	if slot == "" || slot == "." || slot == ".." || strings.ContainsAny(slot, `/\`) {
+		return "", fmt.Errorf("invalid slot name %q", slot)
+	}
+	return filepath.Join(s.dir, slot+slotExtension), nil
+}
+
+// checksum returns checksum of data together with its version, because version selects migrations run on load.
+func checksum(version int, data []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d:", version)
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeAtomic writes content to temporary file in the same directory and renames it to path,
+// so path contains either old or new content even if process is stopped during writing.
+func writeAtomic(path string, content []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,321 @@
+package savegame
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/core"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testState struct {
+	Level int    `json:"level"`
+	Name  string `json:"name"`
+}
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	return NewStoreAt(filepath.Join(t.TempDir(), "saves"))
+}
+
+func newNamedNode(name string) *core.Node {
+	node := core.NewObjectNode(nil)
+	node.SetName(name)
+	return node
+}
+
+func TestSaveLoad(t *testing.T) {
+	store := newTestStore(t)
+
+	player := newNamedNode("player")
+	player.SetPosition(basic.Point{X: 10, Y: 20})
+	player.SetRotation(45)
+	player.SetScale(basic.Point{X: 2, Y: 3})
+	player.SetAlpha(0.5)
+
+	if err := store.Save("slot1", testState{Level: 3, Name: "hero"}, player); err != nil {
+		t.Fatalf("cannot save: %v", err)
+	}
+	if !store.Exists("slot1") {
+		t.Fatalf("saved slot does not exist")
+	}
+
+	var state testState
+	loaded := newNamedNode("player")
+	other := newNamedNode("other")
+	if err := store.Load("slot1", &state, loaded, other); err != nil {
+		t.Fatalf("cannot load: %v", err)
+	}
+
+	if state != (testState{Level: 3, Name: "hero"}) {
+		t.Errorf("loaded state is %+v", state)
+	}
+	if loaded.GetPosition() != player.GetPosition() || loaded.GetRotation() != 45 ||
+		loaded.GetScale() != player.GetScale() || loaded.GetAlpha() != 0.5 {
+		t.Errorf("loaded node state is %+v, expected %+v", GetNodeState(loaded), GetNodeState(player))
+	}
+	if other.GetPosition() != (basic.Point{}) || other.GetAlpha() != 1 {
+		t.Errorf("node without stored state was changed")
+	}
+
+	// no temporary files are left after atomic write
+	entries, err := os.ReadDir(store.GetDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "slot1"+slotExtension {
+		t.Errorf("store directory contains %v, expected only slot file", entries)
+	}
+}
+
+func TestSaveReplacesSlot(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.Save("slot", testState{Level: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("slot", testState{Level: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	var state testState
+	if err := store.Load("slot", &state); err != nil {
+		t.Fatal(err)
+	}
+	if state.Level != 2 {
+		t.Errorf("loaded level is %d, expected 2", state.Level)
+	}
+}
+
+func TestSaveInvalidNodes(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.Save("slot", testState{}, newNamedNode("")); err == nil {
+		t.Errorf("node without name was saved")
+	}
+	if err := store.Save("slot", testState{}, newNamedNode("a"), newNamedNode("a")); err == nil {
+		t.Errorf("nodes with the same name were saved")
+	}
+	if store.Exists("slot") {
+		t.Errorf("slot was written after failed save")
+	}
+}
+
+func TestSlotNames(t *testing.T) {
+	store := newTestStore(t)
+	for _, slot := range []string{"", ".", "..", "a/b", `a\b`, "../escape"} {
+		if err := store.Save(slot, testState{}); err == nil {
+			t.Errorf("slot %q was saved", slot)
+		}
+		if err := store.Load(slot, &testState{}); err == nil || errors.Is(err, ErrNoSlot) {
+			t.Errorf("slot %q: Load returned %v, expected invalid name error", slot, err)
+		}
+		if store.Exists(slot) {
+			t.Errorf("slot %q exists", slot)
+		}
+	}
+}
+
+func TestLoadMissingSlot(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.Load("missing", &testState{}); !errors.Is(err, ErrNoSlot) {
+		t.Errorf("Load returned %v, expected ErrNoSlot", err)
+	}
+}
+
+func TestLoadCorrupted(t *testing.T) {
+	cases := []struct {
+		name    string
+		corrupt func(content []byte) []byte
+	}{
+		{"truncated", func(content []byte) []byte {
+			return content[:len(content)/2]
+		}},
+		{"empty", func(content []byte) []byte {
+			return nil
+		}},
+		{"edited data", func(content []byte) []byte {
+			return []byte(strings.Replace(string(content), `"level":3`, `"level":99`, 1))
+		}},
+		{"edited version", func(content []byte) []byte {
+			return []byte(strings.Replace(string(content), `"version":2`, `"version":1`, 1))
+		}},
+		{"edited checksum", func(content []byte) []byte {
+			var f file
+			if err := json.Unmarshal(content, &f); err != nil {
+				panic(err)
+			}
+			f.Checksum = strings.Repeat("0", len(f.Checksum))
+			content, _ = json.Marshal(f)
+			return content
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			store := newTestStore(t)
+			store.SetVersion(2)
+			// migration would accept edited version, so corruption has to be detected by checksum
+			store.AddMigration(1, func(state json.RawMessage) (json.RawMessage, error) { return state, nil })
+			if err := store.Save("slot", testState{Level: 3}); err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join(store.GetDir(), "slot"+slotExtension)
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			corrupted := c.corrupt(content)
+			if string(corrupted) == string(content) {
+				t.Fatalf("content was not changed")
+			}
+			if err := os.WriteFile(path, corrupted, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := store.Load("slot", &testState{}); !errors.Is(err, ErrCorrupted) {
+				t.Errorf("Load returned %v, expected ErrCorrupted", err)
+			}
+		})
+	}
+}
+
+// renameMigration renames field of state object.
+func renameMigration(from string, to string) Migration {
+	return func(state json.RawMessage) (json.RawMessage, error) {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(state, &fields); err != nil {
+			return nil, err
+		}
+		fields[to] = fields[from]
+		delete(fields, from)
+		return json.Marshal(fields)
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "saves")
+
+	v1 := NewStoreAt(dir)
+	if err := v1.Save("slot", map[string]any{"lvl": 4, "player": "hero"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var applied []int
+	v3 := NewStoreAt(dir)
+	v3.SetVersion(3)
+	v3.AddMigration(2, func(state json.RawMessage) (json.RawMessage, error) {
+		applied = append(applied, 2)
+		return renameMigration("player", "name")(state)
+	})
+	v3.AddMigration(1, func(state json.RawMessage) (json.RawMessage, error) {
+		applied = append(applied, 1)
+		return renameMigration("lvl", "level")(state)
+	})
+
+	var state testState
+	if err := v3.Load("slot", &state); err != nil {
+		t.Fatalf("cannot load: %v", err)
+	}
+	if state != (testState{Level: 4, Name: "hero"}) {
+		t.Errorf("migrated state is %+v", state)
+	}
+	if fmt.Sprint(applied) != "[1 2]" {
+		t.Errorf("migrations applied in order %v, expected [1 2]", applied)
+	}
+
+	slots, err := v3.List()
+	if err != nil || len(slots) != 1 || slots[0].Version != 1 {
+		t.Errorf("List returned %v, %v, expected slot of version 1", slots, err)
+	}
+}
+
+func TestMigrationErrors(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "saves")
+	v2 := NewStoreAt(dir)
+	v2.SetVersion(2)
+	if err := v2.Save("slot", testState{Level: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("missing migration", func(t *testing.T) {
+		v3 := NewStoreAt(dir)
+		v3.SetVersion(3)
+		err := v3.Load("slot", &testState{})
+		if err == nil || !strings.Contains(err.Error(), "no migration from version 2") {
+			t.Errorf("Load returned %v, expected missing migration error", err)
+		}
+	})
+
+	t.Run("failed migration", func(t *testing.T) {
+		v3 := NewStoreAt(dir)
+		v3.SetVersion(3)
+		v3.AddMigration(2, func(state json.RawMessage) (json.RawMessage, error) {
+			return nil, errors.New("broken")
+		})
+		if err := v3.Load("slot", &testState{}); err == nil || !strings.Contains(err.Error(), "broken") {
+			t.Errorf("Load returned %v, expected migration error", err)
+		}
+	})
+
+	t.Run("newer version", func(t *testing.T) {
+		v1 := NewStoreAt(dir)
+		var state testState
+		err := v1.Load("slot", &state)
+		if err == nil || !strings.Contains(err.Error(), "newer than current version") {
+			t.Errorf("Load returned %v, expected newer version error", err)
+		}
+		if state != (testState{}) {
+			t.Errorf("state was changed by failed load")
+		}
+	})
+}
+
+func TestListAndDelete(t *testing.T) {
+	store := newTestStore(t)
+
+	if slots, err := store.List(); err != nil || len(slots) != 0 {
+		t.Errorf("List of store without directory returned %v, %v", slots, err)
+	}
+
+	for _, slot := range []string{"first", "second", "broken"} {
+		if err := store.Save(slot, testState{}); err != nil {
+			t.Fatal(err)
+		}
+		// slots are sorted by time of saving
+		time.Sleep(time.Millisecond)
+	}
+	if err := os.WriteFile(filepath.Join(store.GetDir(), "broken"+slotExtension), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(store.GetDir(), "notes.txt"), []byte("not a slot"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	slots, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, slot := range slots {
+		names = append(names, slot.Name)
+	}
+	// latest first
+	if fmt.Sprint(names) != "[second first]" {
+		t.Errorf("List returned %v, expected [second first]", names)
+	}
+
+	if err := store.Delete("first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("first"); err != nil {
+		t.Errorf("Delete of missing slot returned %v", err)
+	}
+	if store.Exists("first") {
+		t.Errorf("deleted slot exists")
+	}
+}
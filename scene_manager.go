@@ -0,0 +1,157 @@
+package goplayengine
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/core"
+)
+
+// SceneManager keeps stack of scenes, the scene on top of the stack is active scene of the Engine.
+// It is required to use instance initialized by Engine (Engine.GetSceneManager).
+//
+// Changing stack calls lifecycle hooks of scenes (see core.Scene.SetOnEnter and others), and optionally shows
+// Transition between outgoing and incoming scenes.
+type SceneManager struct {
+	stack []*core.Scene
+
+	transition *activeTransition
+
+	onChange func(scene *core.Scene)
+}
+
+// activeTransition is a Transition, which is currently shown.
+type activeTransition struct {
+	transition Transition
+	from       *core.Scene
+	elapsed    float64
+}
+
+func newSceneManager(onChange func(scene *core.Scene)) *SceneManager {
+	return &SceneManager{
+		stack:    make([]*core.Scene, 0),
+		onChange: onChange,
+	}
+}
+
+// Push puts scene on top of the stack, pausing current scene. Transition can be nil.
+// Nil scene is not pushed.
+func (m *SceneManager) Push(scene *core.Scene, transition Transition) {
+	if scene == nil {
+		fmt.Println(fmt.Errorf("cannot push nil scene"))
+		return
+	}
+
+	from := m.Current()
+	if from != nil {
+		from.Pause()
+	}
+
+	m.stack = append(m.stack, scene)
+	scene.Enter()
+
+	m.changed(from, transition)
+}
+
+// Pop removes scene from top of the stack, resuming scene below it. Transition can be nil.
+// Returns removed scene, nil if stack is empty.
+func (m *SceneManager) Pop(transition Transition) *core.Scene {
+	from := m.Current()
+	if from == nil {
+		return nil
+	}
+
+	m.stack = m.stack[:len(m.stack)-1]
+	from.Exit()
+
+	if to := m.Current(); to != nil {
+		to.Resume()
+	}
+
+	m.changed(from, transition)
+	return from
+}
+
+// Replace replaces scene on top of the stack, or pushes scene when stack is empty. Transition can be nil.
+// Nil scene does not replace current scene, use Pop to remove it.
+func (m *SceneManager) Replace(scene *core.Scene, transition Transition) {
+	if scene == nil {
+		fmt.Println(fmt.Errorf("cannot replace scene with nil scene"))
+		return
+	}
+
+	from := m.Current()
+	if from != nil {
+		m.stack = m.stack[:len(m.stack)-1]
+		from.Exit()
+	}
+
+	m.stack = append(m.stack, scene)
+	scene.Enter()
+
+	m.changed(from, transition)
+}
+
+// Current returns scene on top of the stack, nil if stack is empty.
+func (m *SceneManager) Current() *core.Scene {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1]
+}
+
+// GetScenes returns all scenes in the stack, from bottom to top.
+func (m *SceneManager) GetScenes() []*core.Scene {
+	result := make([]*core.Scene, len(m.stack))
+	copy(result, m.stack)
+	return result
+}
+
+// Len returns number of scenes in the stack.
+func (m *SceneManager) Len() int {
+	return len(m.stack)
+}
+
+// InTransition returns true if transition between scenes is currently shown.
+func (m *SceneManager) InTransition() bool {
+	return m.transition != nil
+}
+
+// changed starts transition, if provided, and notifies engine about new active scene.
+// Transition which is still shown is finished immediately.
+func (m *SceneManager) changed(from *core.Scene, transition Transition) {
+	m.transition = nil
+	if transition != nil && transition.GetDuration() > 0 {
+		m.transition = &activeTransition{
+			transition: transition,
+			from:       from,
+			elapsed:    0,
+		}
+	}
+
+	m.onChange(m.Current())
+}
+
+// advance moves shown transition forward by dt seconds.
+func (m *SceneManager) advance(dt float64) {
+	if m.transition == nil {
+		return
+	}
+
+	m.transition.elapsed += dt
+	if m.transition.elapsed >= m.transition.transition.GetDuration() {
+		m.transition = nil
+	}
+}
+
+// renderedScenes returns scenes, that have to be rendered from bottom to top:
+// top scene and all scenes below it, covered only by overlay scenes.
+func (m *SceneManager) renderedScenes() []*core.Scene {
+	if len(m.stack) == 0 {
+		return nil
+	}
+
+	i := len(m.stack) - 1
+	for i > 0 && m.stack[i].IsOverlay() {
+		i--
+	}
+	return m.stack[i:]
+}
@@ -0,0 +1,101 @@
+package scheduler
+
+import "iter"
+
+// Wait describes when coroutine is resumed after yield, created with WaitSeconds, WaitFrame, WaitFrames or WaitUntil.
+type Wait struct {
+	seconds   float64
+	frames    int
+	condition func() bool
+}
+
+// WaitSeconds resumes coroutine after seconds passed.
+func WaitSeconds(seconds float64) Wait {
+	return Wait{seconds: seconds}
+}
+
+// WaitFrame resumes coroutine on the next frame.
+func WaitFrame() Wait {
+	return Wait{frames: 1}
+}
+
+// WaitFrames resumes coroutine after frames passed.
+func WaitFrames(frames int) Wait {
+	return Wait{frames: frames}
+}
+
+// WaitUntil resumes coroutine on the first frame condition returns true, condition is checked starting with the next frame.
+func WaitUntil(condition func() bool) Wait {
+	return Wait{frames: 1, condition: condition}
+}
+
+// Routine is a function run as coroutine, it yields Wait to pause until wait condition is met.
+// When yield returns false coroutine was cancelled, and function have to return.
+//
+//	scheduler.Start(func(yield func(scheduler.Wait) bool) {
+//		node.SetPosition(basic.Point{X: 0, Y: 0})
+//		if !yield(scheduler.WaitSeconds(1)) {
+//			return
+//		}
+//		node.SetPosition(basic.Point{X: 100, Y: 0})
+//	})
+type Routine = iter.Seq[Wait]
+
+// Coroutine is a handle of routine started by Scheduler.
+type Coroutine struct {
+	next func() (Wait, bool)
+	stop func()
+
+	wait    Wait
+	elapsed float64
+	frames  int
+	done    bool
+}
+
+func newCoroutine(routine Routine) *Coroutine {
+	next, stop := iter.Pull(routine)
+	return &Coroutine{next: next, stop: stop}
+}
+
+func (c *Coroutine) Cancel() {
+	if c.done {
+		return
+	}
+	c.done = true
+	c.stop()
+}
+
+func (c *Coroutine) IsActive() bool {
+	return !c.done
+}
+
+// resume runs routine until next yield, or until it returns.
+func (c *Coroutine) resume() {
+	wait, ok := c.next()
+	if !ok {
+		c.done = true
+		return
+	}
+
+	c.wait = wait
+	c.elapsed = 0
+	c.frames = 0
+}
+
+func (c *Coroutine) update(dt float64) {
+	if c.done {
+		return
+	}
+
+	c.elapsed += dt
+	c.frames++
+
+	if c.elapsed < c.wait.seconds || c.frames < c.wait.frames {
+		return
+	}
+	if c.wait.condition != nil && !c.wait.condition() {
+		return
+	}
+
+	c.resume()
+}
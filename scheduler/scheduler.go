@@ -0,0 +1,147 @@
+// Package scheduler runs delayed and repeated callbacks and coroutines.
+//
+// Every core.Scene has own Scheduler, which is updated by Engine every frame while scene is active,
+// so timers and coroutines of paused scenes are paused too. Everything is executed on the thread
+// calling Update, for Engine it is the main thread, where SDL calls are safe.
+package scheduler
+
+// Handle is a timer or coroutine started by Scheduler.
+type Handle interface {
+	// Cancel stops timer or coroutine, it is not called anymore.
+	Cancel()
+	// IsActive returns false if timer or coroutine has finished or was cancelled.
+	IsActive() bool
+}
+
+// Scheduler have to be initialized with NewScheduler.
+type Scheduler struct {
+	timers     []*Timer
+	coroutines []*Coroutine
+	time       float64
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// After calls function once after delay seconds.
+func (s *Scheduler) After(delay float64, function func()) *Timer {
+	t := &Timer{interval: delay, left: delay, function: function}
+	s.timers = append(s.timers, t)
+	return t
+}
+
+// Every calls function every interval seconds until timer is cancelled. If frame takes longer than interval,
+// function is called several times to catch up.
+func (s *Scheduler) Every(interval float64, function func()) *Timer {
+	t := &Timer{interval: interval, left: interval, function: function, repeat: true}
+	s.timers = append(s.timers, t)
+	return t
+}
+
+// Start runs coroutine until its first yield, and then resumes it every Update when its wait condition is met.
+func (s *Scheduler) Start(routine Routine) *Coroutine {
+	c := newCoroutine(routine)
+	c.resume()
+	if c.IsActive() {
+		s.coroutines = append(s.coroutines, c)
+	}
+	return c
+}
+
+// CancelAll cancels all timers and coroutines.
+func (s *Scheduler) CancelAll() {
+	for _, t := range s.timers {
+		t.Cancel()
+	}
+	for _, c := range s.coroutines {
+		c.Cancel()
+	}
+	s.timers = nil
+	s.coroutines = nil
+}
+
+// Len returns number of active timers and coroutines.
+func (s *Scheduler) Len() int {
+	return len(s.timers) + len(s.coroutines)
+}
+
+// GetTime returns time in seconds scheduler was updated for.
+func (s *Scheduler) GetTime() float64 {
+	return s.time
+}
+
+// Update advances timers and coroutines by dt seconds, calling functions which are due.
+// Engine calls it for scheduler of active scene every frame.
+func (s *Scheduler) Update(dt float64) {
+	s.time += dt
+
+	// callbacks may start new timers, they are updated starting with next Update
+	timers := s.timers
+	for _, t := range timers {
+		t.update(dt)
+	}
+
+	coroutines := s.coroutines
+	for _, c := range coroutines {
+		c.update(dt)
+	}
+
+	s.timers = removeInactive(s.timers)
+	s.coroutines = removeInactive(s.coroutines)
+}
+
+// Timer is a handle of function called by Scheduler after delay or repeatedly.
+type Timer struct {
+	interval float64
+	left     float64
+	repeat   bool
+	function func()
+	done     bool
+}
+
+func (t *Timer) Cancel() {
+	t.done = true
+}
+
+func (t *Timer) IsActive() bool {
+	return !t.done
+}
+
+// GetTimeLeft returns time in seconds until next call of function.
+func (t *Timer) GetTimeLeft() float64 {
+	return max(t.left, 0)
+}
+
+func (t *Timer) update(dt float64) {
+	if t.done {
+		return
+	}
+
+	t.left -= dt
+	for t.left <= 0 && !t.done {
+		if !t.repeat {
+			t.done = true
+		}
+		t.function()
+
+		if t.interval <= 0 {
+			// timer without interval is called once per update
+			t.left = t.interval
+			break
+		}
+		t.left += t.interval
+	}
+}
+
+func removeInactive[T Handle](handles []T) []T {
+	result := handles[:0]
+	for _, h := range handles {
+		if h.IsActive() {
+			result = append(result, h)
+		}
+	}
+	// clear tail, so removed handles can be garbage collected
+	clear(handles[len(result):])
+	return result
+}
@@ -0,0 +1,274 @@
+package serialization
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/core"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"github.com/SemyonHoyrish/GoPlayEngine/resource"
+	"os"
+)
+
+// Loader creates scenes and nodes from stored data, loading every image and font only once.
+// Loader have to be initialized with NewLoader.
+type Loader struct {
+	images map[string]*resource.Image
+	fonts  map[string]*resource.Font
+}
+
+func NewLoader() *Loader {
+	return &Loader{
+		images: make(map[string]*resource.Image),
+		fonts:  make(map[string]*resource.Font),
+	}
+}
+
+// AddImage makes loader use already loaded image instead of loading it again by its path.
+func (l *Loader) AddImage(image *resource.Image) {
+	l.images[image.GetPath()] = image
+}
+
+// AddFont makes loader use already loaded font instead of loading it again by its path.
+func (l *Loader) AddFont(font *resource.Font) {
+	l.fonts[font.GetPath()] = font
+}
+
+func (l *Loader) getImage(path string) *resource.Image {
+	if img, ok := l.images[path]; ok {
+		return img
+	}
+	img := resource.NewImage(path)
+	l.images[path] = img
+	return img
+}
+
+func (l *Loader) getFont(path string) *resource.Font {
+	if font, ok := l.fonts[path]; ok {
+		return font
+	}
+	font := resource.NewFont(path)
+	l.fonts[path] = font
+	return font
+}
+
+// Unmarshal creates scene from JSON, see Loader.Unmarshal.
+func Unmarshal(content []byte) (*core.Scene, error) {
+	return NewLoader().Unmarshal(content)
+}
+
+// LoadFile creates scene from JSON file, see Loader.LoadFile.
+func LoadFile(path string) (*core.Scene, error) {
+	return NewLoader().LoadFile(path)
+}
+
+// Unmarshal creates scene from JSON. Unknown fields are reported as errors, so typos in hand-edited files are not lost.
+func (l *Loader) Unmarshal(content []byte) (*core.Scene, error) {
+	var data Scene
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("cannot decode scene: %w", err)
+	}
+	return l.DecodeScene(data)
+}
+
+// UnmarshalNode creates node with children from JSON, see NodeFile.
+func (l *Loader) UnmarshalNode(content []byte) (*core.Node, error) {
+	var data NodeFile
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("cannot decode node: %w", err)
+	}
+	if err := checkVersion(data.Version); err != nil {
+		return nil, err
+	}
+	return l.DecodeNode(data.Node)
+}
+
+// LoadFile creates scene from JSON file.
+func (l *Loader) LoadFile(path string) (*core.Scene, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read scene (%s): %w", path, err)
+	}
+	scene, err := l.Unmarshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load scene (%s): %w", path, err)
+	}
+	return scene, nil
+}
+
+// DecodeScene creates scene from its stored representation.
+func (l *Loader) DecodeScene(data Scene) (*core.Scene, error) {
+	if err := checkVersion(data.Version); err != nil {
+		return nil, err
+	}
+
+	scene := core.NewScene()
+	scene.SetName(data.Name)
+	scene.SetBackgroundColor(decodeColor(data.Background))
+	scene.SetOverlay(data.Overlay)
+
+	for i, n := range data.Nodes {
+		node, err := l.DecodeNode(n)
+		if err != nil {
+			return nil, fmt.Errorf("node %d: %w", i, err)
+		}
+		scene.AddNode(node)
+	}
+
+	return scene, nil
+}
+
+// DecodeNode creates node with children from its stored representation.
+func (l *Loader) DecodeNode(data Node) (*core.Node, error) {
+	var node *core.Node
+
+	switch data.Type {
+	case NodeTypeBase:
+		node = core.NewNode()
+	case NodeTypeObject:
+		var texture *core.Texture
+		if data.Texture != nil {
+			t, err := l.decodeTexture(*data.Texture)
+			if err != nil {
+				return nil, err
+			}
+			texture = t
+		}
+		node = core.NewObjectNode(texture)
+	case NodeTypeText:
+		if data.Text == nil {
+			return nil, fmt.Errorf("text node has no text")
+		}
+		info := &core.NodeTextInfo{
+			Text:     data.Text.Text,
+			TextSize: data.Text.Size,
+			Color:    decodeColor(data.Text.Color),
+		}
+		if data.Text.Font != "" {
+			info.Font = l.getFont(data.Text.Font)
+		}
+		node = core.NewTextNode(info)
+	default:
+		return nil, fmt.Errorf("unknown node type %q", data.Type)
+	}
+
+	node.SetName(data.Name)
+	node.SetPosition(decodePoint(data.Position))
+	if data.Size != nil {
+		node.SetOverrideSize(basic.Size{Width: data.Size.Width, Height: data.Size.Height})
+	}
+	node.SetLayer(data.Layer)
+	node.SetRotation(data.Rotation)
+	if data.Scale != nil {
+		node.SetScale(decodePoint(*data.Scale))
+	}
+	if data.Pivot != nil {
+		node.SetPivot(decodePoint(*data.Pivot))
+	}
+	node.SetFlipHorizontal(data.FlipHorizontal)
+	node.SetFlipVertical(data.FlipVertical)
+	if data.Alpha != nil {
+		node.SetAlpha(*data.Alpha)
+	}
+	node.AutoOverlap(data.AutoOverlap)
+
+	if data.Overlap != nil {
+		overlap, err := decodeOverlap(*data.Overlap)
+		if err != nil {
+			return nil, err
+		}
+		node.SetOverlap(overlap)
+	}
+
+	for i, c := range data.Children {
+		child, err := l.DecodeNode(c)
+		if err != nil {
+			return nil, fmt.Errorf("child %d: %w", i, err)
+		}
+		node.AddChild(child)
+	}
+
+	return node, nil
+}
+
+func (l *Loader) decodeTexture(data Texture) (*core.Texture, error) {
+	if data.Primitive != nil {
+		if data.Image != "" {
+			return nil, fmt.Errorf("texture cannot have both image and primitive")
+		}
+
+		p := data.Primitive
+		color := decodeColor(p.Color)
+		switch p.Type {
+		case PrimitiveRectangle:
+			return core.NewTextureFromPrimitive(primitive.Rectangle{Width: p.Width, Height: p.Height, Color: color}), nil
+		case PrimitiveCircle:
+			return core.NewTextureFromPrimitive(primitive.Circle{Radius: p.Radius, Color: color}), nil
+		case PrimitiveLine:
+			if p.To == nil {
+				return nil, fmt.Errorf("line primitive has no end point")
+			}
+			return core.NewTextureFromPrimitive(primitive.Line{To: decodePoint(*p.To), Color: color}), nil
+		}
+		return nil, fmt.Errorf("unknown primitive type %q", p.Type)
+	}
+
+	if data.Image == "" {
+		return nil, fmt.Errorf("texture has neither image nor primitive")
+	}
+	if data.Region != nil {
+		r := data.Region
+		return core.NewTextureFromRegion(l.getImage(data.Image), basic.Rect{X: r.X, Y: r.Y, Width: r.Width, Height: r.Height}), nil
+	}
+	return core.NewTextureFromImage(l.getImage(data.Image)), nil
+}
+
+func decodeOverlap(data Overlap) (core.OverlapInterface, error) {
+	if data.Overlaps != nil {
+		if data.LeftTop != nil || data.RightBottom != nil {
+			return nil, fmt.Errorf("composed overlap cannot have corners")
+		}
+
+		composed := core.NewComposedOverlap()
+		for _, part := range data.Overlaps {
+			if part.Overlaps != nil {
+				return nil, fmt.Errorf("composed overlap cannot contain composed overlap")
+			}
+			o, err := decodeOverlap(part)
+			if err != nil {
+				return nil, err
+			}
+			composed.Add(o.(*core.Overlap))
+		}
+		return composed, nil
+	}
+
+	if data.LeftTop == nil || data.RightBottom == nil {
+		return nil, fmt.Errorf("overlap has to have both leftTop and rightBottom")
+	}
+	return core.NewOverlap(decodePoint(*data.LeftTop), decodePoint(*data.RightBottom)), nil
+}
+
+// checkVersion returns error if data of the version cannot be read by this package.
+func checkVersion(version int) error {
+	if version <= 0 {
+		return fmt.Errorf("data has no format version")
+	}
+	if version > Version {
+		return fmt.Errorf("format version %d is newer than supported version %d", version, Version)
+	}
+	return nil
+}
+
+func decodePoint(p Point) basic.Point {
+	return basic.Point{X: p.X, Y: p.Y}
+}
+
+func decodeColor(c Color) primitive.Color {
+	return primitive.Color{R: c.R, G: c.G, B: c.B, A: c.A}
+}
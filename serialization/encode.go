@@ -0,0 +1,201 @@
+package serialization
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/core"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"os"
+	"sort"
+)
+
+// Marshal encodes scene into indented JSON.
+func Marshal(scene *core.Scene) ([]byte, error) {
+	data, err := EncodeScene(scene)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(data, "", "  ")
+}
+
+// SaveFile encodes scene into JSON file.
+func SaveFile(path string, scene *core.Scene) error {
+	content, err := Marshal(scene)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("cannot write scene (%s): %w", path, err)
+	}
+	return nil
+}
+
+// MarshalNode encodes node and its children into indented JSON, see NodeFile.
+func MarshalNode(node *core.Node) ([]byte, error) {
+	data, err := EncodeNode(node)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(NodeFile{Version: Version, Node: data}, "", "  ")
+}
+
+// EncodeScene converts scene into its stored representation.
+func EncodeScene(scene *core.Scene) (Scene, error) {
+	data := Scene{
+		Version:    Version,
+		Name:       scene.GetName(),
+		Background: encodeColor(scene.GetBackgroundColor()),
+		Overlay:    scene.IsOverlay(),
+		Nodes:      []Node{},
+	}
+
+	for _, node := range sortedNodes(scene.GetAllNodes()) {
+		n, err := EncodeNode(node)
+		if err != nil {
+			return Scene{}, err
+		}
+		data.Nodes = append(data.Nodes, n)
+	}
+
+	return data, nil
+}
+
+// EncodeNode converts node and its children into stored representation.
+func EncodeNode(node *core.Node) (Node, error) {
+	data := Node{
+		Name:           node.GetName(),
+		Position:       encodePoint(node.GetPosition()),
+		Layer:          node.GetLayer(),
+		Rotation:       node.GetRotation(),
+		FlipHorizontal: node.GetFlipHorizontal(),
+		FlipVertical:   node.GetFlipVertical(),
+		AutoOverlap:    node.AutoOverlapEnabled(),
+	}
+
+	if size := node.GetOverrideSize(); size != (basic.Size{}) {
+		data.Size = &Size{Width: size.Width, Height: size.Height}
+	}
+	if scale := node.GetScale(); scale != (basic.Point{X: 1, Y: 1}) {
+		p := encodePoint(scale)
+		data.Scale = &p
+	}
+	if pivot := node.GetPivot(); pivot != (basic.Point{X: 0.5, Y: 0.5}) {
+		p := encodePoint(pivot)
+		data.Pivot = &p
+	}
+	if alpha := node.GetAlpha(); alpha != 1 {
+		data.Alpha = &alpha
+	}
+
+	switch node.GetType() {
+	case core.NodeTypeBase:
+		data.Type = NodeTypeBase
+	case core.NodeTypeObject:
+		data.Type = NodeTypeObject
+		if t := node.GetTexture(); t != nil {
+			texture, err := encodeTexture(t)
+			if err != nil {
+				return Node{}, fmt.Errorf("cannot encode node (id=%d): %w", node.GetID(), err)
+			}
+			data.Texture = texture
+		}
+	case core.NodeTypeText:
+		data.Type = NodeTypeText
+		if info := node.GetTextInfo(); info != nil {
+			text := &Text{Text: info.Text, Size: info.TextSize, Color: encodeColor(info.Color)}
+			if info.Font != nil {
+				text.Font = info.Font.GetPath()
+			}
+			data.Text = text
+		}
+	default:
+		return Node{}, fmt.Errorf("cannot encode node (id=%d) of unknown type %d", node.GetID(), node.GetType())
+	}
+
+	// overlaps of auto overlap are built again after loading
+	if overlap := node.GetOverlap(); overlap != nil && node.GetAutoOverlap() == nil && !node.IsPartOfAutoOverlap() {
+		o, err := encodeOverlap(overlap)
+		if err != nil {
+			return Node{}, fmt.Errorf("cannot encode node (id=%d): %w", node.GetID(), err)
+		}
+		data.Overlap = &o
+	}
+
+	for _, child := range sortedNodes(node.GetChildren()) {
+		c, err := EncodeNode(child)
+		if err != nil {
+			return Node{}, err
+		}
+		data.Children = append(data.Children, c)
+	}
+
+	return data, nil
+}
+
+func encodeTexture(t *core.Texture) (*Texture, error) {
+	if p := t.GetPrimitive(); p != nil {
+		prim := &Primitive{Color: encodeColor(p.GetColor())}
+		switch p := p.(type) {
+		case primitive.Rectangle:
+			prim.Type = PrimitiveRectangle
+			prim.Width = p.Width
+			prim.Height = p.Height
+		case primitive.Circle:
+			prim.Type = PrimitiveCircle
+			prim.Radius = p.Radius
+		case primitive.Line:
+			prim.Type = PrimitiveLine
+			to := encodePoint(p.To)
+			prim.To = &to
+		default:
+			return nil, fmt.Errorf("cannot encode primitive of type %T", p)
+		}
+		return &Texture{Primitive: prim}, nil
+	}
+
+	if img := t.GetImage(); img != nil {
+		texture := &Texture{Image: img.GetPath()}
+		if r := t.GetRegion(); r != nil {
+			texture.Region = &Rect{X: r.X, Y: r.Y, Width: r.Width, Height: r.Height}
+		}
+		return texture, nil
+	}
+
+	return &Texture{}, nil
+}
+
+func encodeOverlap(overlap core.OverlapInterface) (Overlap, error) {
+	switch o := overlap.(type) {
+	case *core.Overlap:
+		leftTop, rightBottom := encodePoint(o.GetLeftTop()), encodePoint(o.GetRightBottom())
+		return Overlap{LeftTop: &leftTop, RightBottom: &rightBottom}, nil
+	case *core.ComposedOverlap:
+		result := Overlap{Overlaps: []Overlap{}}
+		for _, part := range o.GetOverlaps() {
+			p, err := encodeOverlap(part)
+			if err != nil {
+				return Overlap{}, err
+			}
+			result.Overlaps = append(result.Overlaps, p)
+		}
+		return result, nil
+	}
+	return Overlap{}, fmt.Errorf("cannot encode overlap of type %T", overlap)
+}
+
+// sortedNodes returns nodes in order of creation, so output does not depend on order of sets.
+func sortedNodes(nodes []*core.Node) []*core.Node {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].GetID() < nodes[j].GetID()
+	})
+	return nodes
+}
+
+func encodePoint(p basic.Point) Point {
+	return Point{X: p.X, Y: p.Y}
+}
+
+func encodeColor(c primitive.Color) Color {
+	return Color{R: c.R, G: c.G, B: c.B, A: c.A}
+}
@@ -0,0 +1,122 @@
+// Package serialization saves scenes and node trees to, and loads them from versioned JSON.
+//
+// Scene, node names, types, transforms, sizes, layers, textures (image paths, regions and primitives),
+// text info with font paths, overlaps and children are stored. Functions (update functions, hooks, components),
+// cameras and worlds are not stored and have to be set up in code after loading.
+// Values equal to defaults (e.g. scale {1, 1}) are omitted, and nodes are written in order of creation,
+// so files are short and produce readable diffs.
+package serialization
+
+import "github.com/SemyonHoyrish/GoPlayEngine/core"
+
+// Version is a version of format written by this package.
+const Version = 1
+
+// Node types stored in Node.Type.
+const (
+	NodeTypeBase   = "base"
+	NodeTypeObject = "object"
+	NodeTypeText   = "text"
+)
+
+// Primitive types stored in Primitive.Type.
+const (
+	PrimitiveRectangle = "rectangle"
+	PrimitiveCircle    = "circle"
+	PrimitiveLine      = "line"
+)
+
+// Scene is a stored core.Scene.
+type Scene struct {
+	Version    int    `json:"version"`
+	Name       string `json:"name,omitempty"`
+	Background Color  `json:"background"`
+	Overlay    bool   `json:"overlay,omitempty"`
+	Nodes      []Node `json:"nodes"`
+}
+
+// NodeFile is a stored tree of nodes without scene, e.g. prefab.
+type NodeFile struct {
+	Version int  `json:"version"`
+	Node    Node `json:"node"`
+}
+
+// Node is a stored core.Node with its children.
+type Node struct {
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+	Position Point  `json:"position"`
+	// Size is override size of the node, omitted if not set.
+	Size           *Size          `json:"size,omitempty"`
+	Layer          core.LayerType `json:"layer,omitempty"`
+	Rotation       float32        `json:"rotation,omitempty"`
+	Scale          *Point         `json:"scale,omitempty"`
+	Pivot          *Point         `json:"pivot,omitempty"`
+	FlipHorizontal bool           `json:"flipHorizontal,omitempty"`
+	FlipVertical   bool           `json:"flipVertical,omitempty"`
+	Alpha          *float32       `json:"alpha,omitempty"`
+	AutoOverlap    bool           `json:"autoOverlap,omitempty"`
+
+	Texture *Texture `json:"texture,omitempty"`
+	Text    *Text    `json:"text,omitempty"`
+	Overlap *Overlap `json:"overlap,omitempty"`
+
+	Children []Node `json:"children,omitempty"`
+}
+
+type Point struct {
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+}
+
+type Size struct {
+	Width  float32 `json:"width"`
+	Height float32 `json:"height"`
+}
+
+type Rect struct {
+	X      float32 `json:"x"`
+	Y      float32 `json:"y"`
+	Width  float32 `json:"width"`
+	Height float32 `json:"height"`
+}
+
+type Color struct {
+	R uint8 `json:"r"`
+	G uint8 `json:"g"`
+	B uint8 `json:"b"`
+	A uint8 `json:"a"`
+}
+
+// Texture is either an image with optional region, or a primitive.
+type Texture struct {
+	Image     string     `json:"image,omitempty"`
+	Region    *Rect      `json:"region,omitempty"`
+	Primitive *Primitive `json:"primitive,omitempty"`
+}
+
+// Primitive is a stored primitive, only fields used by its type are stored.
+type Primitive struct {
+	Type   string  `json:"type"`
+	Width  float32 `json:"width,omitempty"`
+	Height float32 `json:"height,omitempty"`
+	Radius float32 `json:"radius,omitempty"`
+	To     *Point  `json:"to,omitempty"`
+	Color  Color   `json:"color"`
+}
+
+// Text is a stored core.NodeTextInfo.
+type Text struct {
+	Text  string `json:"text"`
+	Size  int    `json:"size"`
+	Font  string `json:"font"`
+	Color Color  `json:"color"`
+}
+
+// Overlap is either a rectangle with LeftTop and RightBottom corners (core.Overlap),
+// or a composition of rectangles (core.ComposedOverlap).
+type Overlap struct {
+	LeftTop     *Point    `json:"leftTop,omitempty"`
+	RightBottom *Point    `json:"rightBottom,omitempty"`
+	Overlaps    []Overlap `json:"overlaps,omitempty"`
+}
@@ -0,0 +1,146 @@
+// Package snapshot implements golden-image testing of rendered frames.
+//
+// Typical usage with headless engine:
+//
+//	engine.Step(16)
+//	frame, _ := engine.CaptureFrame()
+//	snapshot.Assert(t, frame, "testdata/menu.png", snapshot.Options{Tolerance: 2})
+//
+// Golden files are created or overwritten when Options.Update is set or UpdateEnv environment variable is "1".
+package snapshot
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// UpdateEnv is a name of environment variable, which enables updating of golden files when set to "1".
+const UpdateEnv = "GOPLAYENGINE_UPDATE_GOLDEN"
+
+// Options describes how images are compared.
+type Options struct {
+	// Tolerance is a maximum allowed difference of each color channel of a pixel.
+	Tolerance uint8
+	// MaxDiffPixels is a number of pixels allowed to differ more than Tolerance.
+	MaxDiffPixels int
+	// DiffPath is a path diff image is written to on failure, "<golden>.diff.png" if empty.
+	DiffPath string
+	// Update makes Compare to write image as a new golden file instead of comparing.
+	Update bool
+}
+
+// Compare compares img with PNG golden file, returns nil if they match.
+// On mismatch, diff image is written (see Options.DiffPath) and error describes the difference.
+func Compare(img image.Image, goldenPath string, opts Options) error {
+	if opts.Update || os.Getenv(UpdateEnv) == "1" {
+		return SavePNG(goldenPath, img)
+	}
+
+	golden, err := LoadPNG(goldenPath)
+	if err != nil {
+		return fmt.Errorf("cannot load golden file (set %s=1 to create it): %w", UpdateEnv, err)
+	}
+
+	if img.Bounds().Size() != golden.Bounds().Size() {
+		return fmt.Errorf("image size %v differs from golden (%s) size %v", img.Bounds().Size(), goldenPath, golden.Bounds().Size())
+	}
+
+	diff, count := Diff(img, golden, opts.Tolerance)
+	if count <= opts.MaxDiffPixels {
+		return nil
+	}
+
+	diffPath := opts.DiffPath
+	if diffPath == "" {
+		diffPath = strings.TrimSuffix(goldenPath, filepath.Ext(goldenPath)) + ".diff.png"
+	}
+	if err := SavePNG(diffPath, diff); err != nil {
+		return fmt.Errorf("%d pixels differ from golden (%s), cannot write diff: %w", count, goldenPath, err)
+	}
+
+	return fmt.Errorf("%d pixels differ from golden (%s), diff written to %s", count, goldenPath, diffPath)
+}
+
+// Assert calls Compare and reports error to t.
+func Assert(t testing.TB, img image.Image, goldenPath string, opts Options) {
+	t.Helper()
+	if err := Compare(img, goldenPath, opts); err != nil {
+		t.Error(err)
+	}
+}
+
+// Diff compares two images of the same size pixel by pixel.
+// Returns image, where pixels differing more than tolerance are red and others are dimmed copy of a,
+// and number of such pixels.
+func Diff(a, b image.Image, tolerance uint8) (*image.RGBA, int) {
+	bounds := a.Bounds()
+	diff := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	count := 0
+
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			ca := color.RGBAModel.Convert(a.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.RGBA)
+			cb := color.RGBAModel.Convert(b.At(b.Bounds().Min.X+x, b.Bounds().Min.Y+y)).(color.RGBA)
+
+			if channelDiff(ca.R, cb.R) > tolerance || channelDiff(ca.G, cb.G) > tolerance ||
+				channelDiff(ca.B, cb.B) > tolerance || channelDiff(ca.A, cb.A) > tolerance {
+				diff.SetRGBA(x, y, color.RGBA{R: 255, A: 255})
+				count++
+			} else {
+				diff.SetRGBA(x, y, color.RGBA{R: ca.R / 4, G: ca.G / 4, B: ca.B / 4, A: 255})
+			}
+		}
+	}
+
+	return diff, count
+}
+
+// LoadPNG reads PNG file and converts it to RGBA image.
+func LoadPNG(path string) (*image.RGBA, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return rgba, nil
+}
+
+// SavePNG writes image to a PNG file, creating parent directories if needed.
+func SavePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,132 @@
+package goplayengine
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+	"runtime"
+	"time"
+)
+
+// sleepMargin is a part of frame time that is waited by spinning instead of sleeping,
+// because OS scheduler may wake up thread later than requested.
+const sleepMargin = 2 * time.Millisecond
+
+// realTime returns high resolution time in seconds.
+func realTime() float64 {
+	return float64(sdl.GetPerformanceCounter()) / float64(sdl.GetPerformanceFrequency())
+}
+
+// getTime returns high resolution time in seconds, virtual time in headless mode.
+func (e *Engine) getTime() float64 {
+	if e.headless {
+		return float64(e.virtualTicks) / 1000
+	}
+	return realTime()
+}
+
+// limitFrameRate waits until it is time to start next frame according to EngineConfig.TargetFPS.
+// Most of the time is slept, and the last sleepMargin is spun for accuracy.
+func (e *Engine) limitFrameRate() {
+	if e.config.TargetFPS <= 0 {
+		e.nextFrameTime = 0
+		return
+	}
+
+	frameTime := 1 / float64(e.config.TargetFPS)
+	now := realTime()
+
+	// Next frame time is advanced by exactly one frame to avoid drift,
+	// but when engine fall behind by more than a frame, schedule is restarted.
+	if e.nextFrameTime == 0 || now-e.nextFrameTime > frameTime {
+		e.nextFrameTime = now
+	}
+	e.nextFrameTime += frameTime
+
+	for {
+		remaining := time.Duration((e.nextFrameTime - realTime()) * float64(time.Second))
+		if remaining <= 0 {
+			return
+		}
+		if remaining > sleepMargin {
+			time.Sleep(remaining - sleepMargin)
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
+
+// SetTargetFPS limits number of frames rendered per second, 0 means no limit.
+func (e *Engine) SetTargetFPS(fps int) {
+	if fps < 0 {
+		fps = 0
+	}
+	e.config.TargetFPS = fps
+}
+
+// GetTargetFPS returns limit of frames rendered per second, 0 means no limit.
+func (e *Engine) GetTargetFPS() int {
+	return e.config.TargetFPS
+}
+
+// updateTime calculates delta time of the current frame.
+func (e *Engine) updateTime() {
+	curTicks := e.GetTicks()
+	e.deltaTime = curTicks - e.previousTicks
+	e.previousTicks = curTicks
+
+	curTime := e.getTime()
+	e.deltaSeconds = curTime - e.previousTime
+	e.previousTime = curTime
+}
+
+// runFixedUpdates calls fixed update function of the active scene as many times as needed
+// to catch up simulation with elapsed time, but not more than EngineConfig.MaxFixedStepsPerFrame.
+func (e *Engine) runFixedUpdates() {
+	if e.config.FixedTickRate <= 0 {
+		e.interpolationAlpha = 1
+		return
+	}
+
+	fixedDelta := e.GetFixedDeltaTime()
+	e.fixedAccumulator += e.deltaSeconds
+
+	steps := 0
+	for e.fixedAccumulator >= fixedDelta && steps < e.config.MaxFixedStepsPerFrame {
+		if e.activeScene == nil {
+			break
+		}
+		if fixedUpdate := e.activeScene.GetFixedUpdateFunction(); fixedUpdate != nil {
+			fixedUpdate()
+		}
+		e.fixedAccumulator -= fixedDelta
+		steps++
+	}
+
+	// Simulation is too slow to catch up, so remaining time is dropped instead of making next frames even slower.
+	if e.fixedAccumulator >= fixedDelta {
+		e.fixedAccumulator = 0
+	}
+
+	e.interpolationAlpha = e.fixedAccumulator / fixedDelta
+}
+
+// GetDeltaSeconds returns difference between two frames in seconds, with higher precision than GetDeltaTime.
+func (e *Engine) GetDeltaSeconds() float64 {
+	return e.deltaSeconds
+}
+
+// GetFixedDeltaTime returns time in seconds simulated by every call of fixed update function,
+// 0 if fixed timestep is disabled.
+func (e *Engine) GetFixedDeltaTime() float64 {
+	if e.config.FixedTickRate <= 0 {
+		return 0
+	}
+	return 1 / e.config.FixedTickRate
+}
+
+// GetInterpolationAlpha returns how far current frame is between last and next fixed update, in range [0, 1).
+// It can be used to interpolate positions of objects moved in fixed update, e.g.
+// previous + (current - previous) * alpha.
+// Always returns 1 when fixed timestep is disabled.
+func (e *Engine) GetInterpolationAlpha() float64 {
+	return e.interpolationAlpha
+}
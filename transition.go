@@ -0,0 +1,85 @@
+package goplayengine
+
+import (
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"github.com/SemyonHoyrish/GoPlayEngine/render"
+)
+
+// Transition describes animation rendered by Engine while active scene is changed in SceneManager.
+type Transition interface {
+	// GetDuration returns duration of transition in seconds.
+	GetDuration() float64
+	// Render draws one frame of transition. Progress is in range [0, 1],
+	// drawFrom and drawTo render outgoing and incoming scenes moved by offset
+	// (drawFrom renders nothing, when there is no outgoing scene).
+	Render(renderer render.Renderer, progress float64, drawFrom func(offset basic.Point), drawTo func(offset basic.Point))
+}
+
+// FadeTransition fades outgoing scene to Color, and then fades incoming scene from it.
+type FadeTransition struct {
+	// Duration of whole transition in seconds.
+	Duration float64
+	Color    primitive.Color
+}
+
+func (t FadeTransition) GetDuration() float64 {
+	return t.Duration
+}
+
+func (t FadeTransition) Render(renderer render.Renderer, progress float64, drawFrom func(offset basic.Point), drawTo func(offset basic.Point)) {
+	var alpha float64
+	if progress < 0.5 {
+		drawFrom(basic.Point{})
+		alpha = progress * 2
+	} else {
+		drawTo(basic.Point{})
+		alpha = (1 - progress) * 2
+	}
+
+	size := renderer.GetOutputSize()
+	c := t.Color
+	c.A = uint8(float64(c.A) * alpha)
+	renderer.FillRect(basic.Rect{Width: size.Width, Height: size.Height}, c)
+}
+
+// SlideDirection describes direction scenes are moved to in SlideTransition.
+type SlideDirection uint32
+
+const (
+	SlideLeft  SlideDirection = iota
+	SlideRight SlideDirection = iota
+	SlideUp    SlideDirection = iota
+	SlideDown  SlideDirection = iota
+)
+
+// SlideTransition moves outgoing scene out of the screen in Direction, while incoming scene follows it.
+type SlideTransition struct {
+	// Duration of whole transition in seconds.
+	Duration  float64
+	Direction SlideDirection
+}
+
+func (t SlideTransition) GetDuration() float64 {
+	return t.Duration
+}
+
+func (t SlideTransition) Render(renderer render.Renderer, progress float64, drawFrom func(offset basic.Point), drawTo func(offset basic.Point)) {
+	size := renderer.GetOutputSize()
+
+	var dir basic.Point
+	switch t.Direction {
+	case SlideLeft:
+		dir = basic.Point{X: -size.Width}
+	case SlideRight:
+		dir = basic.Point{X: size.Width}
+	case SlideUp:
+		dir = basic.Point{Y: -size.Height}
+	case SlideDown:
+		dir = basic.Point{Y: size.Height}
+	}
+
+	p := float32(progress)
+	drawFrom(basic.Point{X: dir.X * p, Y: dir.Y * p})
+	drawTo(basic.Point{X: -dir.X * (1 - p), Y: -dir.Y * (1 - p)})
+}
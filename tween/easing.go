@@ -0,0 +1,83 @@
+package tween
+
+import "math"
+
+// EasingFunc maps progress of tween from 0 to 1 into eased progress, which is 0 at start and 1 at end,
+// but may go outside of [0, 1] in between (e.g. Back and Elastic easings).
+type EasingFunc func(t float64) float64
+
+// Linear does not change progress.
+func Linear(t float64) float64 { return t }
+
+// InQuad, OutQuad and InOutQuad are quadratic easings.
+func InQuad(t float64) float64  { return t * t }
+func OutQuad(t float64) float64 { return 1 - InQuad(1-t) }
+func InOutQuad(t float64) float64 {
+	return inOut(InQuad, t)
+}
+
+// InCubic, OutCubic and InOutCubic are cubic easings.
+func InCubic(t float64) float64  { return t * t * t }
+func OutCubic(t float64) float64 { return 1 - InCubic(1-t) }
+func InOutCubic(t float64) float64 {
+	return inOut(InCubic, t)
+}
+
+// InSine, OutSine and InOutSine are sinusoidal easings.
+func InSine(t float64) float64  { return 1 - math.Cos(t*math.Pi/2) }
+func OutSine(t float64) float64 { return math.Sin(t * math.Pi / 2) }
+func InOutSine(t float64) float64 {
+	return inOut(InSine, t)
+}
+
+// backOvershoot is an amount of overshoot of Back easings.
+const backOvershoot = 1.70158
+
+// InBack, OutBack and InOutBack are easings, which overshoot start or end.
+func InBack(t float64) float64  { return t * t * ((backOvershoot+1)*t - backOvershoot) }
+func OutBack(t float64) float64 { return 1 - InBack(1-t) }
+func InOutBack(t float64) float64 {
+	return inOut(InBack, t)
+}
+
+// InElastic, OutElastic and InOutElastic are easings, which oscillate around start or end.
+func InElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((t*10-10.75)*2*math.Pi/3)
+}
+func OutElastic(t float64) float64 { return 1 - InElastic(1-t) }
+func InOutElastic(t float64) float64 {
+	return inOut(InElastic, t)
+}
+
+// OutBounce, InBounce and InOutBounce are easings, which bounce at start or end.
+func OutBounce(t float64) float64 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+func InBounce(t float64) float64 { return 1 - OutBounce(1-t) }
+func InOutBounce(t float64) float64 {
+	return inOut(InBounce, t)
+}
+
+// inOut builds InOut easing from In easing, first half of progress eases in, second half eases out.
+func inOut(in EasingFunc, t float64) float64 {
+	if t < 0.5 {
+		return in(t*2) / 2
+	}
+	return 1 - in((1-t)*2)/2
+}
@@ -0,0 +1,192 @@
+package tween
+
+// Sequence plays animations one after another.
+// Sequence have to be initialized with NewSequence.
+type Sequence struct {
+	animations []Animation
+	current    int
+
+	repeat     int
+	played     int
+	onComplete func()
+	finished   bool
+}
+
+// NewSequence creates sequence of animations, use Wait for pauses and Call for calling functions between them.
+func NewSequence(animations ...Animation) *Sequence {
+	return &Sequence{animations: animations}
+}
+
+// Append adds animations to the end of sequence.
+func (s *Sequence) Append(animations ...Animation) *Sequence {
+	s.animations = append(s.animations, animations...)
+	return s
+}
+
+// SetRepeat sets how many times sequence is played again after first time, -1 repeats forever.
+func (s *Sequence) SetRepeat(repeat int) *Sequence {
+	s.repeat = repeat
+	return s
+}
+
+// OnComplete sets function called when sequence finishes, after all repetitions.
+func (s *Sequence) OnComplete(onComplete func()) *Sequence {
+	s.onComplete = onComplete
+	return s
+}
+
+func (s *Sequence) Update(dt float64) float64 {
+	if s.finished {
+		return dt
+	}
+
+	for {
+		passStart := dt
+		for s.current < len(s.animations) {
+			dt = s.animations[s.current].Update(dt)
+			if !s.animations[s.current].IsFinished() {
+				return 0
+			}
+			s.current++
+		}
+
+		if s.repeat >= 0 && s.played >= s.repeat {
+			s.finished = true
+			if s.onComplete != nil {
+				s.onComplete()
+			}
+			return dt
+		}
+
+		s.played++
+		s.current = 0
+		for _, a := range s.animations {
+			a.Reset()
+		}
+		if dt <= 0 || dt >= passStart {
+			// next repetition starts with next update, so sequence, which plays no time per repetition
+			// (e.g. empty or containing only Call), repeated forever does not hang
+			return 0
+		}
+	}
+}
+
+func (s *Sequence) IsFinished() bool {
+	return s.finished
+}
+
+func (s *Sequence) Reset() {
+	s.current = 0
+	s.played = 0
+	s.finished = false
+	for _, a := range s.animations {
+		a.Reset()
+	}
+}
+
+// Parallel plays animations at the same time, and finishes when all of them are finished.
+// Parallel have to be initialized with NewParallel.
+type Parallel struct {
+	animations []Animation
+
+	repeat     int
+	played     int
+	onComplete func()
+	finished   bool
+}
+
+// NewParallel creates group of animations played at the same time.
+func NewParallel(animations ...Animation) *Parallel {
+	return &Parallel{animations: animations}
+}
+
+// Add adds animations to the group.
+func (p *Parallel) Add(animations ...Animation) *Parallel {
+	p.animations = append(p.animations, animations...)
+	return p
+}
+
+// SetRepeat sets how many times group is played again after first time, -1 repeats forever.
+func (p *Parallel) SetRepeat(repeat int) *Parallel {
+	p.repeat = repeat
+	return p
+}
+
+// OnComplete sets function called when all animations of the group finish, after all repetitions.
+func (p *Parallel) OnComplete(onComplete func()) *Parallel {
+	p.onComplete = onComplete
+	return p
+}
+
+func (p *Parallel) Update(dt float64) float64 {
+	if p.finished {
+		return dt
+	}
+
+	for {
+		passStart := dt
+		left := dt
+		running := false
+		for _, a := range p.animations {
+			if a.IsFinished() {
+				continue
+			}
+			left = min(left, a.Update(dt))
+			if !a.IsFinished() {
+				running = true
+			}
+		}
+		if running {
+			return 0
+		}
+		dt = left
+
+		if p.repeat >= 0 && p.played >= p.repeat {
+			p.finished = true
+			if p.onComplete != nil {
+				p.onComplete()
+			}
+			return dt
+		}
+
+		p.played++
+		for _, a := range p.animations {
+			a.Reset()
+		}
+		if dt <= 0 || dt >= passStart {
+			// same as in Sequence, group without duration repeated forever would never leave this loop
+			return 0
+		}
+	}
+}
+
+func (p *Parallel) IsFinished() bool {
+	return p.finished
+}
+
+func (p *Parallel) Reset() {
+	p.played = 0
+	p.finished = false
+	for _, a := range p.animations {
+		a.Reset()
+	}
+}
+
+// Wait is an animation, which does nothing for duration seconds, used for pauses in Sequence.
+func Wait(duration float64) Animation {
+	return wait(duration)
+}
+
+// Call is an animation, which calls function and finishes immediately, used in Sequence.
+func Call(function func()) Animation {
+	return wait(0).OnComplete(function)
+}
+
+func wait(duration float64) *Tween {
+	return &Tween{
+		duration: duration,
+		easing:   Linear,
+		start:    func() {},
+		apply:    func(float64) {},
+	}
+}
@@ -0,0 +1,158 @@
+package tween
+
+import (
+	"testing"
+	"time"
+)
+
+// updateWithTimeout fails test if animation does not return from Update, instead of hanging whole test run.
+func updateWithTimeout(t *testing.T, a Animation, dt float64) float64 {
+	t.Helper()
+
+	done := make(chan float64, 1)
+	go func() {
+		done <- a.Update(dt)
+	}()
+
+	select {
+	case left := <-done:
+		return left
+	case <-time.After(time.Second):
+		t.Fatalf("Update has not returned")
+		return 0
+	}
+}
+
+func TestSequenceCarriesLeftTime(t *testing.T) {
+	a, b := &value{}, &value{}
+	s := NewSequence(a.tween(10, 1), Wait(0.5), b.tween(10, 1))
+
+	left := s.Update(2)
+	assertNear(t, "first value", a.v, 10)
+	assertNear(t, "second value", b.v, 5)
+	assertNear(t, "left time", left, 0)
+
+	left = s.Update(1)
+	assertNear(t, "second value", b.v, 10)
+	assertNear(t, "left time", left, 0.5)
+	if !s.IsFinished() {
+		t.Errorf("sequence has not finished")
+	}
+}
+
+func TestSequenceRepeat(t *testing.T) {
+	v := &value{}
+	completed := 0
+	s := NewSequence(v.tweenFromZero(10, 1)).SetRepeat(2).OnComplete(func() { completed++ })
+
+	s.Update(2.5)
+	assertNear(t, "value", v.v, 5)
+	if s.IsFinished() {
+		t.Fatalf("sequence has finished before all repetitions")
+	}
+
+	left := s.Update(1)
+	assertNear(t, "left time", left, 0.5)
+	if !s.IsFinished() || completed != 1 {
+		t.Errorf("sequence finished %v with %d OnComplete calls, expected finished with 1 call", s.IsFinished(), completed)
+	}
+}
+
+func TestSequenceCall(t *testing.T) {
+	v := &value{}
+	var valueAtCall float64
+	s := NewSequence(v.tween(10, 1), Call(func() { valueAtCall = v.v }), Wait(1))
+
+	s.Update(1.5)
+	assertNear(t, "value at call", valueAtCall, 10)
+}
+
+func TestParallelFinishesWithLongest(t *testing.T) {
+	a, b := &value{}, &value{}
+	p := NewParallel(a.tween(10, 1), b.tween(10, 2))
+
+	p.Update(1.5)
+	assertNear(t, "first value", a.v, 10)
+	assertNear(t, "second value", b.v, 7.5)
+	if p.IsFinished() {
+		t.Fatalf("parallel has finished before longest animation")
+	}
+
+	left := p.Update(1)
+	assertNear(t, "left time", left, 0.5)
+	if !p.IsFinished() {
+		t.Errorf("parallel has not finished")
+	}
+}
+
+func TestParallelRepeat(t *testing.T) {
+	a, b := &value{}, &value{}
+	p := NewParallel(a.tweenFromZero(10, 1), b.tweenFromZero(10, 2)).SetRepeat(1)
+
+	p.Update(2.5)
+	assertNear(t, "first value", a.v, 5)
+	assertNear(t, "second value", b.v, 2.5)
+
+	left := p.Update(2)
+	assertNear(t, "left time", left, 0.5)
+	if !p.IsFinished() {
+		t.Errorf("parallel has not finished")
+	}
+}
+
+func TestGroupWithoutDurationRepeatedForever(t *testing.T) {
+	calls := 0
+	call := func() { calls++ }
+
+	cases := []struct {
+		name      string
+		animation Animation
+		calls     int
+	}{
+		{"empty sequence", NewSequence().SetRepeat(-1), 0},
+		{"sequence of call", NewSequence(Call(call)).SetRepeat(-1), 1},
+		{"sequence of zero wait", NewSequence(Wait(0), Wait(0)).SetRepeat(-1), 0},
+		{"empty parallel", NewParallel().SetRepeat(-1), 0},
+		{"parallel of call", NewParallel(Call(call)).SetRepeat(-1), 1},
+		{"nested", NewSequence(NewParallel(Wait(0)).SetRepeat(-1)).SetRepeat(-1), 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			calls = 0
+			for i := 0; i < 3; i++ {
+				if left := updateWithTimeout(t, c.animation, 0.016); left != 0 {
+					t.Errorf("left time is %v, expected 0", left)
+				}
+			}
+			if c.animation.IsFinished() {
+				t.Errorf("group repeated forever has finished")
+			}
+			// one repetition per update
+			if calls != 3*c.calls {
+				t.Errorf("function called %d times, expected %d", calls, 3*c.calls)
+			}
+		})
+	}
+}
+
+func TestManagerRemovesFinished(t *testing.T) {
+	v := &value{}
+	m := NewManager()
+	tw := m.Play(v.tween(10, 1))
+
+	m.Update(0.5)
+	if !m.IsPlaying(tw) {
+		t.Fatalf("tween is not playing")
+	}
+
+	m.SetPaused(true)
+	m.Update(1)
+	assertNear(t, "value", v.v, 5)
+
+	m.SetPaused(false)
+	m.Update(1)
+	if m.IsPlaying(tw) || m.Len() != 0 {
+		t.Errorf("finished tween was not removed")
+	}
+}
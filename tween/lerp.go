@@ -0,0 +1,42 @@
+package tween
+
+import (
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+	"math"
+)
+
+// LerpFloat64 returns value between from and to for progress t.
+func LerpFloat64(from float64, to float64, t float64) float64 {
+	return from + (to-from)*t
+}
+
+// LerpFloat32 returns value between from and to for progress t.
+func LerpFloat32(from float32, to float32, t float64) float32 {
+	return from + (to-from)*float32(t)
+}
+
+// LerpPoint returns point between from and to for progress t.
+func LerpPoint(from basic.Point, to basic.Point, t float64) basic.Point {
+	return basic.Point{X: LerpFloat32(from.X, to.X, t), Y: LerpFloat32(from.Y, to.Y, t)}
+}
+
+// LerpSize returns size between from and to for progress t.
+func LerpSize(from basic.Size, to basic.Size, t float64) basic.Size {
+	return basic.Size{Width: LerpFloat32(from.Width, to.Width, t), Height: LerpFloat32(from.Height, to.Height, t)}
+}
+
+// LerpColor returns color between from and to for progress t, every channel is interpolated separately.
+func LerpColor(from primitive.Color, to primitive.Color, t float64) primitive.Color {
+	return primitive.Color{
+		R: lerpChannel(from.R, to.R, t),
+		G: lerpChannel(from.G, to.G, t),
+		B: lerpChannel(from.B, to.B, t),
+		A: lerpChannel(from.A, to.A, t),
+	}
+}
+
+// lerpChannel interpolates color channel, clamping result, because easings may overshoot.
+func lerpChannel(from uint8, to uint8, t float64) uint8 {
+	return uint8(math.Round(min(max(LerpFloat64(float64(from), float64(to), t), 0), 255)))
+}
@@ -0,0 +1,80 @@
+package tween
+
+// Manager plays animations, removing them when they finish.
+// Manager have to be initialized with NewManager.
+type Manager struct {
+	animations []Animation
+	paused     bool
+}
+
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Play starts animation from the beginning, animation is updated with the next Update of manager.
+func (m *Manager) Play(animation Animation) Animation {
+	animation.Reset()
+	m.Stop(animation)
+	m.animations = append(m.animations, animation)
+	return animation
+}
+
+// Stop removes animation without finishing it, values stay as they are. Returns false if animation was not playing.
+func (m *Manager) Stop(animation Animation) bool {
+	for i, a := range m.animations {
+		if a == animation {
+			m.animations = append(m.animations[:i], m.animations[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// StopAll removes all animations.
+func (m *Manager) StopAll() {
+	m.animations = nil
+}
+
+// IsPlaying returns true if animation is played by manager.
+func (m *Manager) IsPlaying(animation Animation) bool {
+	for _, a := range m.animations {
+		if a == animation {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns number of playing animations.
+func (m *Manager) Len() int {
+	return len(m.animations)
+}
+
+// SetPaused pauses or resumes all animations.
+func (m *Manager) SetPaused(paused bool) {
+	m.paused = paused
+}
+
+// IsPaused returns true if manager is paused.
+func (m *Manager) IsPaused() bool {
+	return m.paused
+}
+
+// Update advances all animations by dt seconds and removes finished ones. Engine calls it for its manager every frame.
+func (m *Manager) Update(dt float64) {
+	if m.paused {
+		return
+	}
+
+	// animations may be played or stopped by callbacks, so slice is copied
+	animations := append([]Animation(nil), m.animations...)
+	for _, a := range animations {
+		if !m.IsPlaying(a) {
+			continue
+		}
+		a.Update(dt)
+		if a.IsFinished() {
+			m.Stop(a)
+		}
+	}
+}
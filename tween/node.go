@@ -0,0 +1,50 @@
+package tween
+
+import (
+	"fmt"
+	"github.com/SemyonHoyrish/GoPlayEngine/basic"
+	"github.com/SemyonHoyrish/GoPlayEngine/core"
+	"github.com/SemyonHoyrish/GoPlayEngine/primitive"
+)
+
+// MoveTo creates tween changing position of the node relative to its parent.
+func MoveTo(node *core.Node, to basic.Point, duration float64) *Tween {
+	return New(node.GetPosition, node.SetPosition, to, duration, LerpPoint)
+}
+
+// ResizeTo creates tween changing override size of the node, starting from its calculated size.
+func ResizeTo(node *core.Node, to basic.Size, duration float64) *Tween {
+	return New(node.GetCalculatedSize, node.SetOverrideSize, to, duration, LerpSize)
+}
+
+// RotateTo creates tween changing rotation of the node in degrees.
+func RotateTo(node *core.Node, degrees float32, duration float64) *Tween {
+	return Float32(node.GetRotation, node.SetRotation, degrees, duration)
+}
+
+// ScaleTo creates tween changing scale of the node.
+func ScaleTo(node *core.Node, to basic.Point, duration float64) *Tween {
+	return New(node.GetScale, node.SetScale, to, duration, LerpPoint)
+}
+
+// FadeTo creates tween changing opacity of the node (see core.Node.SetAlpha).
+func FadeTo(node *core.Node, alpha float32, duration float64) *Tween {
+	return Float32(node.GetAlpha, node.SetAlpha, alpha, duration)
+}
+
+// ColorTo creates tween changing color of text node or primitive of object node (see core.Node.SetColor),
+// returns error if node has no color.
+func ColorTo(node *core.Node, to primitive.Color, duration float64) (*Tween, error) {
+	if _, ok := node.GetColor(); !ok {
+		return nil, fmt.Errorf("node has no color (id=%d)", node.GetID())
+	}
+
+	get := func() primitive.Color {
+		c, _ := node.GetColor()
+		return c
+	}
+	set := func(c primitive.Color) {
+		_ = node.SetColor(c)
+	}
+	return New(get, set, to, duration, LerpColor), nil
+}
@@ -0,0 +1,163 @@
+// Package tween smoothly changes values over time using easing curves.
+//
+// Tweens change a single value, e.g. position of a node, and can be combined into Sequence and Parallel groups.
+// Animations are played by Manager, Engine has its own manager (Engine.GetTweens) ticked every frame.
+package tween
+
+// Animation is a tween or a group of animations advanced by Manager.
+type Animation interface {
+	// Update advances animation by dt seconds, returns time left after animation has finished during this update,
+	// so groups can pass it to the next animation.
+	Update(dt float64) float64
+	// IsFinished returns true if animation has finished.
+	IsFinished() bool
+	// Reset returns animation into state before its first update.
+	Reset()
+}
+
+// Tween changes single value from its value at start of tween to target value.
+// Tween have to be initialized with New or one of the functions creating tween for node properties.
+type Tween struct {
+	duration   float64
+	delay      float64
+	easing     EasingFunc
+	repeat     int
+	yoyo       bool
+	onComplete func()
+
+	// start reads initial value when tween starts, apply sets value for eased progress
+	start func()
+	apply func(progress float64)
+
+	waited   float64
+	elapsed  float64
+	played   int
+	reversed bool
+	started  bool
+	finished bool
+}
+
+// New creates tween changing value from the value returned by get at start of tween to value to,
+// lerp returns value between from and to for progress t, where t is 0 at from and 1 at to.
+func New[T any](get func() T, set func(T), to T, duration float64, lerp func(from T, to T, t float64) T) *Tween {
+	var from T
+	return &Tween{
+		duration: duration,
+		easing:   Linear,
+		start: func() {
+			from = get()
+		},
+		apply: func(progress float64) {
+			set(lerp(from, to, progress))
+		},
+	}
+}
+
+// Float64 creates tween changing float64 value.
+func Float64(get func() float64, set func(float64), to float64, duration float64) *Tween {
+	return New(get, set, to, duration, LerpFloat64)
+}
+
+// Float32 creates tween changing float32 value.
+func Float32(get func() float32, set func(float32), to float32, duration float64) *Tween {
+	return New(get, set, to, duration, LerpFloat32)
+}
+
+// SetEasing sets easing curve of tween, Linear by default.
+func (t *Tween) SetEasing(easing EasingFunc) *Tween {
+	t.easing = easing
+	return t
+}
+
+// SetDelay sets time in seconds to wait before tween starts.
+func (t *Tween) SetDelay(delay float64) *Tween {
+	t.delay = delay
+	return t
+}
+
+// SetRepeat sets how many times tween is played again after first time, -1 repeats forever.
+func (t *Tween) SetRepeat(repeat int) *Tween {
+	t.repeat = repeat
+	return t
+}
+
+// SetYoyo makes every repetition of tween play in opposite direction, so value goes back and forth.
+func (t *Tween) SetYoyo(yoyo bool) *Tween {
+	t.yoyo = yoyo
+	return t
+}
+
+// OnComplete sets function called when tween finishes, after all repetitions.
+func (t *Tween) OnComplete(onComplete func()) *Tween {
+	t.onComplete = onComplete
+	return t
+}
+
+func (t *Tween) Update(dt float64) float64 {
+	if t.finished {
+		return dt
+	}
+
+	if t.waited < t.delay {
+		t.waited += dt
+		if t.waited < t.delay {
+			return 0
+		}
+		dt = t.waited - t.delay
+	}
+
+	if !t.started {
+		t.started = true
+		t.start()
+	}
+
+	for {
+		t.elapsed += dt
+		if t.elapsed < t.duration {
+			t.apply(t.progress(t.elapsed / t.duration))
+			return 0
+		}
+
+		dt = t.elapsed - t.duration
+		t.elapsed = 0
+		t.apply(t.progress(1))
+
+		if t.repeat >= 0 && t.played >= t.repeat {
+			t.finished = true
+			if t.onComplete != nil {
+				t.onComplete()
+			}
+			return dt
+		}
+
+		t.played++
+		if t.yoyo {
+			t.reversed = !t.reversed
+		}
+		if t.duration <= 0 {
+			// tween without duration repeated forever would never leave this loop
+			return 0
+		}
+	}
+}
+
+func (t *Tween) IsFinished() bool {
+	return t.finished
+}
+
+func (t *Tween) Reset() {
+	t.waited = 0
+	t.elapsed = 0
+	t.played = 0
+	t.reversed = false
+	t.started = false
+	t.finished = false
+}
+
+// progress returns eased progress for linear progress p of current repetition.
+func (t *Tween) progress(p float64) float64 {
+	if t.reversed {
+		p = 1 - p
+	}
+	return t.easing(p)
+}
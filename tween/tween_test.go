@@ -0,0 +1,116 @@
+package tween
+
+import (
+	"math"
+	"testing"
+)
+
+// value is a float64 changed by tweens in tests.
+type value struct {
+	v float64
+}
+
+func (v *value) tween(to float64, duration float64) *Tween {
+	return Float64(func() float64 { return v.v }, func(x float64) { v.v = x }, to, duration)
+}
+
+// tweenFromZero creates tween, which starts from 0 instead of current value, so every repetition of group
+// containing it plays the same way.
+func (v *value) tweenFromZero(to float64, duration float64) *Tween {
+	return Float64(func() float64 { return 0 }, func(x float64) { v.v = x }, to, duration)
+}
+
+func assertNear(t *testing.T, name string, got float64, expected float64) {
+	t.Helper()
+	if math.Abs(got-expected) > 1e-9 {
+		t.Errorf("%s is %v, expected %v", name, got, expected)
+	}
+}
+
+func TestTweenUpdate(t *testing.T) {
+	cases := []struct {
+		name     string
+		setup    func(tw *Tween)
+		updates  []float64
+		value    float64
+		left     float64
+		finished bool
+	}{
+		{"progress", func(tw *Tween) {}, []float64{0.25}, 2.5, 0, false},
+		{"finish returns left time", func(tw *Tween) {}, []float64{0.5, 0.75}, 10, 0.25, true},
+		{"delay", func(tw *Tween) { tw.SetDelay(0.5) }, []float64{0.25}, 0, 0, false},
+		{"delay carries over", func(tw *Tween) { tw.SetDelay(0.5) }, []float64{0.25, 0.5}, 2.5, 0, false},
+		{"delay and finish in one update", func(tw *Tween) { tw.SetDelay(0.5) }, []float64{1.75}, 10, 0.25, true},
+		{"repeat carries over", func(tw *Tween) { tw.SetRepeat(2) }, []float64{2.5}, 5, 0, false},
+		{"repeat finishes", func(tw *Tween) { tw.SetRepeat(2) }, []float64{2.5, 0.75}, 10, 0.25, true},
+		{"repeat forever", func(tw *Tween) { tw.SetRepeat(-1) }, []float64{100.25}, 2.5, 0, false},
+		{"yoyo goes back", func(tw *Tween) { tw.SetRepeat(1).SetYoyo(true) }, []float64{1.25}, 7.5, 0, false},
+		{"yoyo finishes at start", func(tw *Tween) { tw.SetRepeat(1).SetYoyo(true) }, []float64{1.25, 1}, 0, 0.25, true},
+		{"yoyo repeated forward again", func(tw *Tween) { tw.SetRepeat(2).SetYoyo(true) }, []float64{2.25}, 2.5, 0, false},
+		{"easing", func(tw *Tween) { tw.SetEasing(InQuad) }, []float64{0.5}, 2.5, 0, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := &value{}
+			tw := v.tween(10, 1)
+			c.setup(tw)
+
+			var left float64
+			for _, dt := range c.updates {
+				left = tw.Update(dt)
+			}
+			assertNear(t, "value", v.v, c.value)
+			assertNear(t, "left time", left, c.left)
+			if tw.IsFinished() != c.finished {
+				t.Errorf("finished is %v, expected %v", tw.IsFinished(), c.finished)
+			}
+		})
+	}
+}
+
+func TestTweenStartsFromCurrentValue(t *testing.T) {
+	v := &value{v: 4}
+	tw := v.tween(10, 1).SetDelay(1)
+	v.v = 6 // value changed during delay is used as start
+	tw.Update(1.5)
+	assertNear(t, "value", v.v, 8)
+}
+
+func TestTweenOnCompleteAndReset(t *testing.T) {
+	v := &value{}
+	completed := 0
+	tw := v.tween(10, 1).SetRepeat(1).OnComplete(func() { completed++ })
+
+	tw.Update(1.5)
+	if completed != 0 {
+		t.Fatalf("OnComplete called before all repetitions")
+	}
+	tw.Update(1)
+	if completed != 1 {
+		t.Fatalf("OnComplete called %d times, expected 1", completed)
+	}
+	tw.Update(1)
+	if completed != 1 {
+		t.Fatalf("OnComplete called again after tween has finished")
+	}
+
+	tw.Reset()
+	v.v = 0
+	if tw.IsFinished() {
+		t.Fatalf("tween is finished after Reset")
+	}
+	tw.Update(0.5)
+	assertNear(t, "value", v.v, 5)
+}
+
+func TestTweenWithoutDurationRepeatedForever(t *testing.T) {
+	calls := 0
+	tw := Call(func() { calls++ }).(*Tween)
+	tw.SetRepeat(-1)
+
+	updateWithTimeout(t, tw, 0.016)
+	if tw.IsFinished() {
+		t.Errorf("tween repeated forever has finished")
+	}
+}